@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/lmika/audax/internal/common/awsconfig"
+	"github.com/lmika/audax/internal/common/userconfig"
+	"github.com/lmika/audax/internal/dynamo-browse/headless"
+	"github.com/lmika/audax/internal/dynamo-browse/services/tables"
+	"github.com/pkg/errors"
+)
+
+// headlessCommands are subcommands which run against a table without starting the UI
+var headlessCommands = map[string]func(ctx context.Context, args []string) error{
+	"query":  runQuery,
+	"export": runExport,
+	"put":    runPut,
+	"delete": runDelete,
+}
+
+// runHeadless runs the headless subcommand, if one was given, and exits.
+func runHeadless(args []string) {
+	if len(args) == 0 {
+		return
+	}
+	cmd, isCmd := headlessCommands[args[0]]
+	if !isCmd {
+		return
+	}
+
+	if err := cmd(context.Background(), args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "dynamo-browse %v: %v\n", args[0], err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+type headlessFlags struct {
+	fs       *flag.FlagSet
+	table    *string
+	local    *string
+	limit    *int
+	awsFlags *awsconfig.Options
+
+	// args are the positional arguments, which can appear before, after or between the flags
+	args []string
+}
+
+func newHeadlessFlags(name string, usage string) *headlessFlags {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dynamo-browse %v %v\n", name, usage)
+		fs.PrintDefaults()
+	}
+
+	return &headlessFlags{
+		fs:       fs,
+		table:    fs.String("t", "", "dynamodb table name"),
+		local:    fs.String("local", "", "local endpoint"),
+		limit:    fs.Int("limit", 0, "maximum number of items to scan or query"),
+		awsFlags: awsconfig.FlagSet(fs),
+	}
+}
+
+// parse parses the flags and returns the positional arguments joined as a single query.  Flags may appear
+// after positional arguments, such as `query -t table 'pk = "x"' -o jsonl`.  Arguments after "--" are always
+// treated as positional arguments.
+func (hf *headlessFlags) parse(args []string) (string, error) {
+	for {
+		if err := hf.fs.Parse(args); err != nil {
+			return "", err
+		}
+
+		rest := hf.fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			hf.args = append(hf.args, rest...)
+			break
+		} else if len(rest) == 0 {
+			break
+		}
+
+		hf.args = append(hf.args, rest[0])
+		args = rest[1:]
+	}
+
+	if *hf.table == "" {
+		return "", errors.New("table name required: use -t")
+	}
+	return strings.Join(hf.args, " "), nil
+}
+
+func (hf *headlessFlags) runner(ctx context.Context) (*headless.Runner, error) {
+	userConfig, err := userconfig.Load()
+	if err != nil {
+		return nil, err
+	}
+	userConfig.ApplyAWSDefaults(hf.awsFlags)
+	hf.awsFlags.TokenProvider = stderrTokenProvider
+
+	localEndpoint, err := parseLocalEndpoint(*hf.local, userConfig.DynamoBrowse.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	connector := &dynamoConnector{localEndpoint: localEndpoint}
+	if _, err := connector.Connect(ctx, *hf.awsFlags); err != nil {
+		return nil, err
+	}
+
+	tableService := tables.NewService(connector.provider)
+	tableService.SetScanLimit(userConfig.DynamoBrowse.ScanLimit)
+	if *hf.limit > 0 {
+		tableService.SetScanLimit(*hf.limit)
+	}
+
+	return headless.NewRunner(tableService, os.Stdout, os.Stderr), nil
+}
+
+func runQuery(ctx context.Context, args []string) error {
+	hf := newHeadlessFlags("query", "-t TABLE [flags] [QUERY]")
+	format := hf.fs.String("o", string(headless.FormatJSONL), "output format: jsonl, json, csv or dynamodb-jsonl")
+
+	query, err := hf.parse(args)
+	if err != nil {
+		return err
+	}
+	outputFormat, err := headless.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	runner, err := hf.runner(ctx)
+	if err != nil {
+		return err
+	}
+	return runner.Query(ctx, *hf.table, query, outputFormat)
+}
+
+func runExport(ctx context.Context, args []string) error {
+	hf := newHeadlessFlags("export", "-t TABLE -f FILE [flags] [QUERY]")
+	format := hf.fs.String("o", string(headless.FormatCSV), "output format: jsonl, json, csv or dynamodb-jsonl")
+	filename := hf.fs.String("f", "", "file to export to")
+
+	query, err := hf.parse(args)
+	if err != nil {
+		return err
+	}
+	if *filename == "" {
+		return errors.New("export file required: use -f")
+	}
+	outputFormat, err := headless.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	runner, err := hf.runner(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := runner.Export(ctx, *hf.table, query, outputFormat, *filename)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d items exported to %v\n", n, *filename)
+	return nil
+}
+
+func runPut(ctx context.Context, args []string) error {
+	hf := newHeadlessFlags("put", "-t TABLE [flags] [FILE]")
+	format := hf.fs.String("i", string(headless.FormatJSONL), "input format: jsonl or dynamodb-jsonl")
+
+	if _, err := hf.parse(args); err != nil {
+		return err
+	}
+	inputFormat, err := headless.ParseInputFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	var in io.Reader = os.Stdin
+	if len(hf.args) > 1 {
+		return errors.New("expected at most one input file")
+	} else if len(hf.args) == 1 && hf.args[0] != "-" {
+		f, err := os.Open(hf.args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	runner, err := hf.runner(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := runner.Put(ctx, *hf.table, in, inputFormat)
+	fmt.Fprintf(os.Stderr, "%d items put\n", n)
+	return err
+}
+
+func runDelete(ctx context.Context, args []string) error {
+	hf := newHeadlessFlags("delete", "-t TABLE [flags] QUERY")
+	all := hf.fs.Bool("all", false, "delete all scanned items when no query is given")
+
+	query, err := hf.parse(args)
+	if err != nil {
+		return err
+	}
+	if query == "" && !*all {
+		return errors.New("query required: use -all to delete all scanned items")
+	}
+
+	runner, err := hf.runner(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := runner.Delete(ctx, *hf.table, query)
+	fmt.Fprintf(os.Stderr, "%d items deleted\n", n)
+	return err
+}
+
+// stderrTokenProvider prompts for an MFA token on stderr so that it does not mix with the output
+func stderrTokenProvider() (string, error) {
+	fmt.Fprint(os.Stderr, "MFA token code: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", errors.Wrap(err, "cannot read MFA token")
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -4,69 +4,112 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lmika/audax/internal/common/awsconfig"
 	"github.com/lmika/audax/internal/common/ui/commandctrl"
 	"github.com/lmika/audax/internal/common/ui/logging"
 	"github.com/lmika/audax/internal/common/ui/osstyle"
+	"github.com/lmika/audax/internal/common/userconfig"
 	"github.com/lmika/audax/internal/dynamo-browse/controllers"
 	"github.com/lmika/audax/internal/dynamo-browse/providers/dynamo"
+	"github.com/lmika/audax/internal/dynamo-browse/providers/userstore"
+	"github.com/lmika/audax/internal/dynamo-browse/services/savedqueries"
 	"github.com/lmika/audax/internal/dynamo-browse/services/tables"
 	"github.com/lmika/audax/internal/dynamo-browse/ui"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/keybindings"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/styles"
 	"github.com/lmika/gopkgs/cli"
+	"github.com/pkg/errors"
 	"log"
 	"net"
 	"os"
 )
 
 func main() {
+	runHeadless(os.Args[1:])
+
 	var flagTable = flag.String("t", "", "dynamodb table name")
 	var flagLocal = flag.String("local", "", "local endpoint")
 	var flagDebug = flag.String("debug", "", "file to log debug messages")
+	var awsFlags = awsconfig.Flags()
 	flag.Parse()
 
 	ctx := context.Background()
 
-	cfg, err := config.LoadDefaultConfig(ctx)
+	userConfig, err := userconfig.Load()
 	if err != nil {
-		cli.Fatalf("cannot load AWS config: %v", err)
+		cli.Fatalf("%v", err)
 	}
+	userConfig.ApplyAWSDefaults(awsFlags)
 
-	var dynamoClient *dynamodb.Client
-	if *flagLocal != "" {
-		host, port, err := net.SplitHostPort(*flagLocal)
-		if err != nil {
-			cli.Fatalf("invalid address '%v': %v", *flagLocal, err)
-		}
-		if host == "" {
-			host = "localhost"
-		}
-		if port == "" {
-			port = "8000"
-		}
-		dynamoClient = dynamodb.NewFromConfig(cfg,
-			dynamodb.WithEndpointResolver(dynamodb.EndpointResolverFromURL(fmt.Sprintf("http://%v:%v", host, port))))
-	} else {
-		dynamoClient = dynamodb.NewFromConfig(cfg)
+	keyBindings := keybindings.Default()
+	if err := userconfig.ApplyKeyBindings(keyBindings, userConfig.DynamoBrowse.Keys); err != nil {
+		cli.Fatalf("%v", err)
+	}
+	uiStyles := styles.DefaultStyles
+	if err := userconfig.ApplyStyles(&uiStyles, userConfig.DynamoBrowse.Styles); err != nil {
+		cli.Fatalf("%v", err)
+	}
+
+	localEndpoint, err := parseLocalEndpoint(*flagLocal, userConfig.DynamoBrowse.Endpoint)
+	if err != nil {
+		cli.Fatalf("%v", err)
 	}
 
-	dynamoProvider := dynamo.NewProvider(dynamoClient)
+	tokenProvider := awsconfig.NewTUITokenProvider()
+	awsFlags.TokenProvider = tokenProvider.Token
+
+	connector := &dynamoConnector{localEndpoint: localEndpoint}
+	awsOptions, err := connector.Connect(ctx, *awsFlags)
+	if err != nil {
+		cli.Fatalf("%v", err)
+	}
+
+	dynamoProvider := connector.provider
 
 	tableService := tables.NewService(dynamoProvider)
+	tableService.SetScanLimit(userConfig.DynamoBrowse.ScanLimit)
+
+	userStoreDir, err := userstore.DefaultDir()
+	if err != nil {
+		cli.Fatalf("%v", err)
+	}
+	userStore, err := userstore.NewStore(userStoreDir)
+	if err != nil {
+		cli.Fatalf("cannot open user store: %v", err)
+	}
+	savedQueryService := savedqueries.NewService(userStore)
 
 	state := controllers.NewState()
 	tableReadController := controllers.NewTableReadController(state, tableService, *flagTable)
 	tableWriteController := controllers.NewTableWriteController(state, tableService, tableReadController)
+	tableAdminController := controllers.NewTableAdminController(state, tableService, tableReadController)
+	connectionController := controllers.NewConnectionController(connector, tableReadController, awsOptions)
+	savedQueryController := controllers.NewSavedQueryController(state, savedQueryService, tableReadController)
+
+	scriptController := controllers.NewScriptController(state, tableReadController, tableWriteController)
+	for _, script := range userConfig.DynamoBrowse.Scripts {
+		if err := scriptController.LoadScript(script); err != nil {
+			cli.Fatalf("%v", err)
+		}
+	}
 
 	commandController := commandctrl.NewCommandController()
-	model := ui.NewModel(tableReadController, tableWriteController, commandController)
+	commandController.AddMacros(userConfig.DynamoBrowse.Macros)
+	if startupFile, err := userconfig.StartupFile("dynamo-browse"); err == nil {
+		commandController.SetStartupFile(startupFile)
+	}
+	model := ui.NewModel(tableReadController, tableWriteController, tableAdminController, connectionController, savedQueryController, scriptController, commandController, keyBindings, uiStyles)
 
 	// Pre-determine if layout has dark background.  This prevents calls for creating a list to hang.
 	lipgloss.HasDarkBackground()
 
 	p := tea.NewProgram(model, tea.WithAltScreen())
+	commandController.SetPublisher(p)
+	scriptController.SetPublisher(p)
+	tokenProvider.SetPublisher(p)
 
 	closeFn := logging.EnableLogging(*flagDebug)
 	defer closeFn()
@@ -94,3 +137,54 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// parseLocalEndpoint returns the endpoint URL of the local address flag.  If the flag is empty, the
+// default endpoint is returned.
+func parseLocalEndpoint(local string, defaultEndpoint string) (string, error) {
+	if local == "" {
+		return defaultEndpoint, nil
+	}
+
+	host, port, err := net.SplitHostPort(local)
+	if err != nil {
+		return "", errors.Errorf("invalid address '%v': %v", local, err)
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	if port == "" {
+		port = "8000"
+	}
+	return fmt.Sprintf("http://%v:%v", host, port), nil
+}
+
+// dynamoConnector creates DynamoDB clients for the selected profile and region.  The first connection will create
+// the provider; subsequent connections will replace the provider's client.
+type dynamoConnector struct {
+	localEndpoint string
+	provider      *dynamo.Provider
+}
+
+func (dc *dynamoConnector) Connect(ctx context.Context, opts awsconfig.Options) (awsconfig.Options, error) {
+	cfg, err := awsconfig.Load(ctx, opts)
+	if err != nil {
+		return awsconfig.Options{}, err
+	}
+
+	var dynamoClient *dynamodb.Client
+	if dc.localEndpoint != "" {
+		dynamoClient = dynamodb.NewFromConfig(cfg,
+			dynamodb.WithEndpointResolver(dynamodb.EndpointResolverFromURL(dc.localEndpoint)))
+	} else {
+		dynamoClient = dynamodb.NewFromConfig(cfg)
+	}
+
+	if dc.provider == nil {
+		dc.provider = dynamo.NewProvider(dynamoClient)
+	} else {
+		dc.provider.SetClient(dynamoClient)
+	}
+
+	opts.Region = cfg.Region
+	return opts, nil
+}
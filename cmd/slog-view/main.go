@@ -7,9 +7,12 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lmika/audax/internal/common/ui/commandctrl"
 	"github.com/lmika/audax/internal/common/ui/logging"
+	"github.com/lmika/audax/internal/common/userconfig"
 	"github.com/lmika/audax/internal/slog-view/controllers"
 	"github.com/lmika/audax/internal/slog-view/services/logreader"
+	"github.com/lmika/audax/internal/slog-view/styles"
 	"github.com/lmika/audax/internal/slog-view/ui"
+	"github.com/lmika/audax/internal/slog-view/ui/keybindings"
 	"github.com/lmika/gopkgs/cli"
 	"os"
 )
@@ -25,6 +28,20 @@ func main() {
 	// Pre-determine if layout has dark background.  This prevents calls for creating a list to hang.
 	lipgloss.HasDarkBackground()
 
+	userConfig, err := userconfig.Load()
+	if err != nil {
+		cli.Fatalf("%v", err)
+	}
+
+	keyBindings := keybindings.Default()
+	if err := userconfig.ApplyKeyBindings(keyBindings, userConfig.SlogView.Keys); err != nil {
+		cli.Fatalf("%v", err)
+	}
+	uiStyles := styles.DefaultStyles
+	if err := userconfig.ApplyStyles(&uiStyles, userConfig.SlogView.Styles); err != nil {
+		cli.Fatalf("%v", err)
+	}
+
 	closeFn := logging.EnableLogging(*flagDebug)
 	defer closeFn()
 
@@ -33,6 +50,10 @@ func main() {
 	ctrl := controllers.NewLogFileController(service, flag.Arg(0))
 
 	cmdController := commandctrl.NewCommandController()
+	cmdController.AddMacros(userConfig.SlogView.Macros)
+	if startupFile, err := userconfig.StartupFile("slog-view"); err == nil {
+		cmdController.SetStartupFile(startupFile)
+	}
 	//cmdController.AddCommands(&commandctrl.CommandContext{
 	//	Commands: map[string]commandctrl.Command{
 	//		"cd": func(args []string) tea.Cmd {
@@ -41,9 +62,10 @@ func main() {
 	//	},
 	//})
 
-	model := ui.NewModel(ctrl, cmdController)
+	model := ui.NewModel(ctrl, cmdController, keyBindings, uiStyles)
 
 	p := tea.NewProgram(model, tea.WithAltScreen())
+	cmdController.SetPublisher(p)
 
 	if err := p.Start(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
@@ -7,42 +7,88 @@ import (
 	"log"
 	"os"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/awsconfig"
+	"github.com/lmika/audax/internal/common/ui/commandctrl"
 	"github.com/lmika/audax/internal/common/ui/dispatcher"
+	uievents "github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/common/userconfig"
 	"github.com/lmika/audax/internal/sqs-browse/controllers"
 	"github.com/lmika/audax/internal/sqs-browse/models"
 	sqsprovider "github.com/lmika/audax/internal/sqs-browse/providers/sqs"
 	"github.com/lmika/audax/internal/sqs-browse/providers/stormstore"
 	"github.com/lmika/audax/internal/sqs-browse/services/messages"
 	"github.com/lmika/audax/internal/sqs-browse/services/pollmessage"
+	"github.com/lmika/audax/internal/sqs-browse/services/queues"
+	"github.com/lmika/audax/internal/sqs-browse/services/workspace"
+	"github.com/lmika/audax/internal/sqs-browse/styles"
 	"github.com/lmika/audax/internal/sqs-browse/ui"
+	"github.com/lmika/audax/internal/sqs-browse/ui/keybindings"
 	"github.com/lmika/events"
 	"github.com/lmika/gopkgs/cli"
 )
 
 func main() {
-	var flagQueue = flag.String("q", "", "queue to poll")
+	var flagQueue = flag.String("q", "", "name or URL of queue to poll (default: select from list)")
 	var flagTarget = flag.String("t", "", "target queue to push to")
+	var flagWorkspace = flag.String("workspace", "", "workspace file to store captured messages (default: temporary file)")
+	var flagPeek = flag.Bool("peek", false, "peek at messages, releasing them back to the queue once polling stops unless deleted")
+	var flagFIFOGroup = flag.String("fifo-group", models.DefaultMessageGroupID, "group ID of messages sent to FIFO queues without one")
+	var flagFIFODedup = flag.String("fifo-dedup", string(models.DeduplicationContentHash), "deduplication ID of messages sent to FIFO queues: content, original or random")
+	var awsFlags = awsconfig.Flags()
 	flag.Parse()
 
+	dedupStrategy, err := models.ParseDeduplicationStrategy(*flagFIFODedup)
+	if err != nil {
+		cli.Fatalf("%v", err)
+	}
+
+	userConfig, err := userconfig.Load()
+	if err != nil {
+		cli.Fatalf("%v", err)
+	}
+	userConfig.ApplyAWSDefaults(awsFlags)
+
+	keyBindings := keybindings.Default()
+	if err := userconfig.ApplyKeyBindings(keyBindings, userConfig.SQSBrowse.Keys); err != nil {
+		cli.Fatalf("%v", err)
+	}
+	uiStyles := styles.DefaultStyles
+	if err := userconfig.ApplyStyles(&uiStyles, userConfig.SQSBrowse.Styles); err != nil {
+		cli.Fatalf("%v", err)
+	}
+
+	tokenProvider := awsconfig.NewTUITokenProvider()
+	awsFlags.TokenProvider = tokenProvider.Token
+
 	ctx := context.Background()
-	cfg, err := config.LoadDefaultConfig(ctx)
+	cfg, err := awsconfig.Load(ctx, *awsFlags)
 	if err != nil {
-		cli.Fatalf("cannot load AWS config: %v", err)
+		cli.Fatalf("%v", err)
+	}
+
+	var sqsClient *sqs.Client
+	if userConfig.SQSBrowse.Endpoint != "" {
+		sqsClient = sqs.NewFromConfig(cfg,
+			sqs.WithEndpointResolver(sqs.EndpointResolverFromURL(userConfig.SQSBrowse.Endpoint)))
+	} else {
+		sqsClient = sqs.NewFromConfig(cfg)
 	}
-	sqsClient := sqs.NewFromConfig(cfg)
 
 	bus := events.New()
 
-	workspaceFile, err := os.CreateTemp("", "sqs-browse*.workspace")
-	if err != nil {
-		cli.Fatalf("cannot create workspace file: %v", err)
+	workspaceFilename := *flagWorkspace
+	if workspaceFilename == "" {
+		workspaceFile, err := os.CreateTemp("", "sqs-browse*.workspace")
+		if err != nil {
+			cli.Fatalf("cannot create workspace file: %v", err)
+		}
+		workspaceFile.Close() // We just need the filename
+		workspaceFilename = workspaceFile.Name()
 	}
-	workspaceFile.Close() // We just need the filename
 
-	msgStore, err := stormstore.NewStore(workspaceFile.Name())
+	msgStore, err := stormstore.NewStore(workspaceFilename)
 	if err != nil {
 		cli.Fatalf("cannot open workspace: %v", err)
 	}
@@ -50,19 +96,41 @@ func main() {
 
 	sqsProvider := sqsprovider.NewProvider(sqsClient)
 
-	messageService := messages.NewService(sqsProvider)
-	pollService := pollmessage.NewService(msgStore, sqsProvider, *flagQueue, bus)
+	messageService := messages.NewService(sqsProvider, models.FIFOOptions{
+		GroupID:       *flagFIFOGroup,
+		Deduplication: dedupStrategy,
+	})
+	pollMode := models.PollModeConsume
+	if *flagPeek {
+		pollMode = models.PollModePeek
+	}
+	pollService := pollmessage.NewService(msgStore, sqsProvider, pollMode, bus)
 
 	msgSendingHandlers := controllers.NewMessageSendingController(messageService, *flagTarget)
+	pollController := controllers.NewPollController(pollService, queues.NewService(sqsProvider), *flagQueue)
+	redriveController := controllers.NewRedriveController(messageService, pollService)
+	workspaceController := controllers.NewWorkspaceController(workspace.NewService(msgStore))
+
+	cmdController := commandctrl.NewCommandController()
+	cmdController.AddMacros(userConfig.SQSBrowse.Macros)
+	if startupFile, err := userconfig.StartupFile("sqs-browse"); err == nil {
+		cmdController.SetStartupFile(startupFile)
+	}
 
 	loopback := &msgLoopback{}
 	uiDispatcher := dispatcher.NewDispatcher(loopback)
 
-	uiModel := ui.NewModel(uiDispatcher, msgSendingHandlers)
+	uiModel := ui.NewModel(uiDispatcher, cmdController, msgSendingHandlers, pollController, redriveController, workspaceController, keyBindings, uiStyles)
 	p := tea.NewProgram(uiModel, tea.WithAltScreen())
 	loopback.program = p
+	cmdController.SetPublisher(p)
+	tokenProvider.SetPublisher(p)
 
 	bus.On("new-messages", func(m []*models.Message) { p.Send(ui.NewMessagesEvent(m)) })
+	bus.On("poll-error", func(err error) { p.Send(uievents.Error(err)) })
+	bus.On("peek-complete", func(queue string) {
+		p.Send(uievents.StatusMsg("Peeked at all messages on " + models.QueueName(queue) + ": select the queue again to refresh"))
+	})
 
 	f, err := tea.LogToFile("debug.log", "debug")
 	if err != nil {
@@ -71,15 +139,15 @@ func main() {
 	}
 	defer f.Close()
 
-	log.Printf("workspace file: %v", workspaceFile.Name())
+	log.Printf("workspace file: %v", workspaceFilename)
 
-	go func() {
-		if err := pollService.Poll(context.Background()); err != nil {
-			log.Printf("cannot start poller: %v", err)
-		}
-	}()
+	err = p.Start()
 
-	if err := p.Start(); err != nil {
+	if err := pollService.Stop(context.Background()); err != nil {
+		log.Printf("cannot release messages: %v", err)
+	}
+
+	if err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		os.Exit(1)
 	}
@@ -5,94 +5,110 @@ import (
 	"flag"
 	"log"
 	"os"
-	"path/filepath"
+	"os/signal"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
-	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
-	"github.com/pkg/errors"
+	"github.com/lmika/audax/internal/common/awsconfig"
+	"github.com/lmika/audax/internal/common/userconfig"
+	"github.com/lmika/audax/internal/sqs-browse/models/filterexpr"
+	sqsprovider "github.com/lmika/audax/internal/sqs-browse/providers/sqs"
+	"github.com/lmika/audax/internal/sqs-browse/services/drain"
 
 	"github.com/lmika/gopkgs/cli"
 )
 
 func main() {
 	flagQueue := flag.String("q", "", "URL of queue to drain")
-	flagDir := flag.String("dir", "", "directory to save messages")
+	flagDir := flag.String("dir", "", "directory to save message bodies, one file per message")
+	flagJSONL := flag.String("jsonl", "", "JSONL file to save messages with their attributes and metadata, or '-' for stdout")
+	flagKeep := flag.Bool("keep", false, "keep messages on the queue instead of deleting them once saved")
+	flagMax := flag.Int("n", 0, "stop after draining this many messages (default: no limit)")
+	flagTimeout := flag.Duration("timeout", 0, "stop draining after this long (default: no limit)")
+	flagFilter := flag.String("filter", "", "only drain messages matching this filter expression, such as '$.detail.type == \"OrderPlaced\"'")
+	flagWait := flag.Bool("wait", false, "keep waiting for new messages using long polling instead of stopping once the queue is empty")
+	awsFlags := awsconfig.Flags()
 	flag.Parse()
 
 	if *flagQueue == "" {
 		cli.Fatalf("-q flag needs to be specified")
+	} else if *flagDir != "" && *flagJSONL != "" {
+		cli.Fatalf("only one of -dir or -jsonl can be specified")
 	}
 
-	ctx := context.Background()
-	cfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		cli.Fatalf("cannot load AWS config: %v", err)
+	var filter *filterexpr.FilterExpr
+	if *flagFilter != "" {
+		var err error
+		if filter, err = filterexpr.Parse(*flagFilter); err != nil {
+			cli.Fatalf("%v", err)
+		}
 	}
 
-	outDir := *flagDir
-	if outDir == "" {
-		outDir = "out-" + time.Now().Format("20060102150405")
+	userConfig, err := userconfig.Load()
+	if err != nil {
+		cli.Fatalf("%v", err)
 	}
+	userConfig.ApplyAWSDefaults(awsFlags)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	if err := os.MkdirAll(outDir, 0755); err != nil {
-		cli.Fatalf("unable to create out dir: %v", err)
+	awsFlags.TokenProvider = stscreds.StdinTokenProvider
+	cfg, err := awsconfig.Load(ctx, *awsFlags)
+	if err != nil {
+		cli.Fatalf("%v", err)
 	}
 
-	client := sqs.NewFromConfig(cfg)
-	msgCount := 0
-	for {
-		out, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
-			QueueUrl:            aws.String(*flagQueue),
-			MaxNumberOfMessages: 10,
-			WaitTimeSeconds:     1,
-		})
+	var writer drain.MessageWriter
+	switch {
+	case *flagJSONL == "-":
+		writer = newJSONLWriter("stdout", os.Stdout)
+	case *flagJSONL != "":
+		outFile, err := os.Create(*flagJSONL)
 		if err != nil {
-			log.Fatalf("error receiving messages: %v", err)
-			break
-		} else if len(out.Messages) == 0 {
-			break
+			cli.Fatalf("unable to create out file: %v", err)
 		}
-
-		messagesToDelete := make([]types.DeleteMessageBatchRequestEntry, 0, 10)
-		for _, msg := range out.Messages {
-			if err := handleMessage(ctx, outDir, msg); err == nil {
-				messagesToDelete = append(messagesToDelete, types.DeleteMessageBatchRequestEntry{
-					Id:            msg.MessageId,
-					ReceiptHandle: msg.ReceiptHandle,
-				})
-				msgCount += 1
-			} else {
-				log.Println(err)
-			}
+		defer outFile.Close()
+		writer = newJSONLWriter(*flagJSONL, outFile)
+	default:
+		outDir := *flagDir
+		if outDir == "" {
+			outDir = "out-" + time.Now().Format("20060102150405")
 		}
-		if len(messagesToDelete) == 0 {
-			log.Printf("no messages handled, terminating")
-			break
+		if writer, err = newDirWriter(outDir); err != nil {
+			cli.Fatalf("%v", err)
 		}
+	}
 
-		if _, err := client.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
-			QueueUrl: aws.String(*flagQueue),
-			Entries:  messagesToDelete,
-		}); err != nil {
-			log.Printf("error deleting messages from queue: %v", err)
-			break
-		}
+	var client *sqs.Client
+	if userConfig.SQSDrain.Endpoint != "" {
+		client = sqs.NewFromConfig(cfg,
+			sqs.WithEndpointResolver(sqs.EndpointResolverFromURL(userConfig.SQSDrain.Endpoint)))
+	} else {
+		client = sqs.NewFromConfig(cfg)
 	}
 
-	log.Printf("Handled %v messages", msgCount)
-}
+	if *flagTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *flagTimeout)
+		defer cancel()
+	}
 
-func handleMessage(ctx context.Context, outDir string, msg types.Message) error {
-	outFile := filepath.Join(outDir, aws.ToString(msg.MessageId)+".json")
-	msgBody := aws.ToString(msg.Body)
+	drainService := drain.NewService(sqsprovider.NewProvider(client))
+	res, err := drainService.Drain(ctx, *flagQueue, writer, drain.Options{
+		Keep:        *flagKeep,
+		Filter:      filter,
+		MaxMessages: *flagMax,
+		Wait:        *flagWait,
+	})
 
-	log.Printf("%v -> %v", aws.ToString(msg.MessageId), outFile)
-	if err := os.WriteFile(outFile, []byte(msgBody), 0644); err != nil {
-		return errors.Wrapf(err, "unable to write message %v to file %v", msg.MessageId, outFile)
+	if filter != nil {
+		log.Printf("Handled %v messages, skipped %v not matching filter", res.Drained, res.Skipped)
+	} else {
+		log.Printf("Handled %v messages", res.Drained)
+	}
+	if err != nil {
+		cli.Fatalf("error draining queue: %v", err)
 	}
-
-	return nil
 }
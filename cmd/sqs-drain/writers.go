@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/pkg/errors"
+)
+
+// dirWriter writes the body of each message to a separate file within a directory.
+type dirWriter struct {
+	dir string
+}
+
+func newDirWriter(dir string) (*dirWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "unable to create out dir")
+	}
+	return &dirWriter{dir: dir}, nil
+}
+
+func (dw *dirWriter) WriteMessage(msg models.Message) error {
+	outFile := filepath.Join(dw.dir, msg.ExtID+".json")
+
+	log.Printf("%v -> %v", msg.ExtID, outFile)
+	if err := os.WriteFile(outFile, []byte(msg.Data), 0644); err != nil {
+		return errors.Wrapf(err, "unable to write message %v to file %v", msg.ExtID, outFile)
+	}
+	return nil
+}
+
+// jsonlWriter writes each message, along with its attributes and metadata, as a line of a JSONL file.
+type jsonlWriter struct {
+	name    string
+	encoder *json.Encoder
+}
+
+func newJSONLWriter(name string, w io.Writer) *jsonlWriter {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &jsonlWriter{name: name, encoder: encoder}
+}
+
+func (jw *jsonlWriter) WriteMessage(msg models.Message) error {
+	if err := jw.encoder.Encode(models.NewMessageRecord(msg)); err != nil {
+		return errors.Wrapf(err, "unable to write message %v", msg.ExtID)
+	}
+	log.Printf("%v -> %v", msg.ExtID, jw.name)
+	return nil
+}
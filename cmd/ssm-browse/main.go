@@ -4,16 +4,19 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lmika/audax/internal/common/awsconfig"
 	"github.com/lmika/audax/internal/common/ui/commandctrl"
 	"github.com/lmika/audax/internal/common/ui/logging"
+	"github.com/lmika/audax/internal/common/userconfig"
 	"github.com/lmika/audax/internal/ssm-browse/controllers"
 	"github.com/lmika/audax/internal/ssm-browse/providers/awsssm"
 	"github.com/lmika/audax/internal/ssm-browse/services/ssmparameters"
+	"github.com/lmika/audax/internal/ssm-browse/styles"
 	"github.com/lmika/audax/internal/ssm-browse/ui"
+	"github.com/lmika/audax/internal/ssm-browse/ui/keybindings"
 	"github.com/lmika/gopkgs/cli"
 	"os"
 )
@@ -21,6 +24,7 @@ import (
 func main() {
 	var flagLocal = flag.Bool("local", false, "local endpoint")
 	var flagDebug = flag.String("debug", "", "file to log debug messages")
+	var awsFlags = awsconfig.Flags()
 	flag.Parse()
 
 	// Pre-determine if layout has dark background.  This prevents calls for creating a list to hang.
@@ -29,15 +33,38 @@ func main() {
 	closeFn := logging.EnableLogging(*flagDebug)
 	defer closeFn()
 
-	cfg, err := config.LoadDefaultConfig(context.Background())
+	userConfig, err := userconfig.Load()
 	if err != nil {
-		cli.Fatalf("cannot load AWS config: %v", err)
+		cli.Fatalf("%v", err)
 	}
+	userConfig.ApplyAWSDefaults(awsFlags)
 
-	var ssmClient *ssm.Client
+	keyBindings := keybindings.Default()
+	if err := userconfig.ApplyKeyBindings(keyBindings, userConfig.SSMBrowse.Keys); err != nil {
+		cli.Fatalf("%v", err)
+	}
+	uiStyles := styles.DefaultStyles
+	if err := userconfig.ApplyStyles(&uiStyles, userConfig.SSMBrowse.Styles); err != nil {
+		cli.Fatalf("%v", err)
+	}
+
+	tokenProvider := awsconfig.NewTUITokenProvider()
+	awsFlags.TokenProvider = tokenProvider.Token
+
+	cfg, err := awsconfig.Load(context.Background(), *awsFlags)
+	if err != nil {
+		cli.Fatalf("%v", err)
+	}
+
+	endpoint := userConfig.SSMBrowse.Endpoint
 	if *flagLocal {
+		endpoint = "http://localhost:4566"
+	}
+
+	var ssmClient *ssm.Client
+	if endpoint != "" {
 		ssmClient = ssm.NewFromConfig(cfg,
-			ssm.WithEndpointResolver(ssm.EndpointResolverFromURL("http://localhost:4566")))
+			ssm.WithEndpointResolver(ssm.EndpointResolverFromURL(endpoint)))
 	} else {
 		ssmClient = ssm.NewFromConfig(cfg)
 	}
@@ -48,17 +75,29 @@ func main() {
 	ctrl := controllers.New(service)
 
 	cmdController := commandctrl.NewCommandController()
+	cmdController.AddMacros(userConfig.SSMBrowse.Macros)
+	if startupFile, err := userconfig.StartupFile("ssm-browse"); err == nil {
+		cmdController.SetStartupFile(startupFile)
+	}
 	cmdController.AddCommands(&commandctrl.CommandContext{
 		Commands: map[string]commandctrl.Command{
 			"cd": func(args []string) tea.Cmd {
 				return ctrl.ChangePrefix(args[0])
 			},
 		},
+		Help: map[string]commandctrl.CommandHelp{
+			"cd": {
+				Description: "change the parameter prefix",
+				Args:        []commandctrl.ArgSpec{{Name: "prefix"}},
+			},
+		},
 	})
 
-	model := ui.NewModel(ctrl, cmdController)
+	model := ui.NewModel(ctrl, cmdController, keyBindings, uiStyles)
 
 	p := tea.NewProgram(model, tea.WithAltScreen())
+	cmdController.SetPublisher(p)
+	tokenProvider.SetPublisher(p)
 
 	if err := p.Start(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
@@ -0,0 +1,88 @@
+package awsconfig
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/pkg/errors"
+)
+
+// Options are the user selectable options used to load the AWS config.  Fields that are empty will
+// use the default values, as determined by the environment and shared config files.
+type Options struct {
+	Profile string
+	Region  string
+
+	// RoleARN is the ARN of a role to assume using the credentials of the profile
+	RoleARN string
+
+	// MFASerial is the serial number or ARN of the MFA device to use when assuming RoleARN
+	MFASerial string
+
+	// TokenProvider is used to retrieve an MFA token when one is required, either by the profile or
+	// when assuming RoleARN with MFASerial set.
+	TokenProvider func() (string, error)
+}
+
+// String returns a short description of the options suitable for display in the status bar.
+func (o Options) String() string {
+	var parts []string
+	if o.Profile != "" {
+		parts = append(parts, "profile: "+o.Profile)
+	}
+	if o.RoleARN != "" {
+		parts = append(parts, "role: "+roleName(o.RoleARN))
+	}
+	if o.Region != "" {
+		parts = append(parts, "region: "+o.Region)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Load loads the AWS config using the passed in options.  If a role ARN is set, the returned config will
+// use credentials of the assumed role, which are cached until they expire.
+func Load(ctx context.Context, opts Options) (aws.Config, error) {
+	var loadOpts []func(*config.LoadOptions) error
+	if opts.Profile != "" {
+		loadOpts = append(loadOpts, config.WithSharedConfigProfile(opts.Profile))
+	}
+	if opts.Region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(opts.Region))
+	}
+	if opts.TokenProvider != nil {
+		loadOpts = append(loadOpts, config.WithAssumeRoleCredentialOptions(func(aro *stscreds.AssumeRoleOptions) {
+			aro.TokenProvider = opts.TokenProvider
+		}))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return aws.Config{}, errors.Wrap(err, "cannot load AWS config")
+	}
+
+	if opts.RoleARN != "" {
+		if opts.MFASerial != "" && opts.TokenProvider == nil {
+			return aws.Config{}, errors.New("MFA serial set but there is no way to prompt for a token")
+		}
+
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), opts.RoleARN, func(aro *stscreds.AssumeRoleOptions) {
+			if opts.MFASerial != "" {
+				aro.SerialNumber = aws.String(opts.MFASerial)
+				aro.TokenProvider = opts.TokenProvider
+			}
+		}))
+	}
+
+	return cfg, nil
+}
+
+func roleName(roleARN string) string {
+	if idx := strings.LastIndex(roleARN, "/"); idx >= 0 {
+		return roleARN[idx+1:]
+	}
+	return roleARN
+}
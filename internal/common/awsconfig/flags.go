@@ -0,0 +1,19 @@
+package awsconfig
+
+import "flag"
+
+// Flags registers the common AWS flags on the default flag set.  The returned options will be populated once
+// the flags are parsed.
+func Flags() *Options {
+	return FlagSet(flag.CommandLine)
+}
+
+// FlagSet registers the common AWS flags on the passed in flag set.
+func FlagSet(fs *flag.FlagSet) *Options {
+	opts := &Options{}
+	fs.StringVar(&opts.Profile, "profile", "", "AWS profile to use")
+	fs.StringVar(&opts.Region, "region", "", "AWS region to use")
+	fs.StringVar(&opts.RoleARN, "role-arn", "", "ARN of role to assume")
+	fs.StringVar(&opts.MFASerial, "mfa-serial", "", "serial number of MFA device to use when assuming a role")
+	return opts
+}
@@ -0,0 +1,93 @@
+package awsconfig
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/pkg/errors"
+)
+
+const (
+	// tokenPromptTimeout is the amount of time to wait for the user to enter an MFA token
+	tokenPromptTimeout = 5 * time.Minute
+
+	// tokenPromptSendTimeout is the amount of time to wait for the program to accept the prompt
+	tokenPromptSendTimeout = 5 * time.Second
+)
+
+// MessagePublisher sends messages to a running tea program
+type MessagePublisher interface {
+	Send(msg tea.Msg)
+}
+
+// TUITokenProvider prompts for MFA tokens using the prompt of a running tea program.  Token is safe to be
+// called from any goroutine other than the one running the program's update loop.
+type TUITokenProvider struct {
+	mutex     *sync.Mutex
+	publisher MessagePublisher
+}
+
+func NewTUITokenProvider() *TUITokenProvider {
+	return &TUITokenProvider{mutex: new(sync.Mutex)}
+}
+
+// SetPublisher sets the publisher used to send the prompt.  This needs to be set once the program is created.
+func (tp *TUITokenProvider) SetPublisher(publisher MessagePublisher) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+	tp.publisher = publisher
+}
+
+type tokenResult struct {
+	token string
+	err   error
+}
+
+// Token prompts the user for an MFA token and blocks until one is entered.  An error is returned straight away
+// if the user cancels the prompt, or if the prompt cannot be shown, such as when another prompt is active.
+func (tp *TUITokenProvider) Token() (string, error) {
+	tp.mutex.Lock()
+	publisher := tp.publisher
+	tp.mutex.Unlock()
+
+	if publisher == nil {
+		return "", errors.New("MFA token required but unable to prompt for one")
+	}
+
+	resultChan := make(chan tokenResult, 1)
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		publisher.Send(events.PromptForInputMsg{
+			Prompt: "MFA token: ",
+			OnDone: func(value string) tea.Cmd {
+				resultChan <- tokenResult{token: value}
+				return nil
+			},
+			OnCancel: func() tea.Cmd {
+				resultChan <- tokenResult{err: errors.New("MFA token prompt cancelled or unavailable")}
+				return nil
+			},
+		})
+	}()
+
+	select {
+	case <-sent:
+	case <-time.After(tokenPromptSendTimeout):
+		return "", errors.New("unable to prompt for MFA token")
+	}
+
+	select {
+	case res := <-resultChan:
+		if res.err != nil {
+			return "", res.err
+		} else if res.token == "" {
+			return "", errors.New("no MFA token entered")
+		}
+		return res.token, nil
+	case <-time.After(tokenPromptTimeout):
+		return "", errors.New("timeout waiting for MFA token")
+	}
+}
@@ -0,0 +1,55 @@
+// Package clipboard copies text to the system clipboard.
+package clipboard
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/pkg/errors"
+)
+
+// ttyDevice is the terminal the OSC52 escape sequence is written to.  The sequence is written to the terminal
+// directly, rather than to stdout, so that it cannot interleave with output written by the UI.
+const ttyDevice = "/dev/tty"
+
+// Write copies the text to the system clipboard.  When running over SSH, or when the system clipboard is not
+// available, the text is copied using the OSC52 escape sequence, which is supported by most terminals.
+func Write(text string) error {
+	if !isRemoteSession() {
+		if err := clipboard.WriteAll(text); err == nil {
+			return nil
+		}
+	}
+
+	tty, err := os.OpenFile(ttyDevice, os.O_WRONLY, 0)
+	if err != nil {
+		return errors.Wrap(err, "cannot copy to clipboard")
+	}
+	defer tty.Close()
+
+	if _, err := io.WriteString(tty, OSC52Sequence(text)); err != nil {
+		return errors.Wrap(err, "cannot copy to clipboard")
+	}
+	return nil
+}
+
+// OSC52Sequence returns the OSC52 escape sequence setting the clipboard to text.  If running within tmux or
+// screen, the sequence is wrapped so that it is passed through to the terminal.
+func OSC52Sequence(text string) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+
+	switch {
+	case os.Getenv("TMUX") != "":
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		return "\x1bP" + seq + "\x1b\\"
+	}
+	return seq
+}
+
+func isRemoteSession() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
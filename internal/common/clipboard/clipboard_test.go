@@ -0,0 +1,31 @@
+package clipboard_test
+
+import (
+	"testing"
+
+	"github.com/lmika/audax/internal/common/clipboard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOSC52Sequence(t *testing.T) {
+	t.Run("should return sequence for terminal", func(t *testing.T) {
+		t.Setenv("TMUX", "")
+		t.Setenv("TERM", "xterm-256color")
+
+		assert.Equal(t, "\x1b]52;c;aGVsbG8=\a", clipboard.OSC52Sequence("hello"))
+	})
+
+	t.Run("should wrap sequence for tmux", func(t *testing.T) {
+		t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+		t.Setenv("TERM", "screen-256color")
+
+		assert.Equal(t, "\x1bPtmux;\x1b\x1b]52;c;aGVsbG8=\a\x1b\\", clipboard.OSC52Sequence("hello"))
+	})
+
+	t.Run("should wrap sequence for screen", func(t *testing.T) {
+		t.Setenv("TMUX", "")
+		t.Setenv("TERM", "screen")
+
+		assert.Equal(t, "\x1bP\x1b]52;c;aGVsbG8=\a\x1b\\", clipboard.OSC52Sequence("hello"))
+	})
+}
@@ -0,0 +1,29 @@
+package editor
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/lmika/shellwords"
+)
+
+// defaultEditor is the editor used if neither $VISUAL or $EDITOR is set
+const defaultEditor = "vi"
+
+// Command returns the command which opens the file in the user's editor.  The editor is taken from $VISUAL
+// or $EDITOR, which may include arguments.
+func Command(filename string) *exec.Cmd {
+	editorCmd := []string{defaultEditor}
+	for _, envVar := range []string{"VISUAL", "EDITOR"} {
+		if args := shellwords.Split(os.Getenv(envVar)); len(args) > 0 {
+			editorCmd = args
+			break
+		}
+	}
+
+	cmd := exec.Command(editorCmd[0], append(editorCmd[1:], filename)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
@@ -1,23 +1,66 @@
 package commandctrl
 
 import (
+	"bufio"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pkg/errors"
 	"log"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/lmika/audax/internal/common/ui/events"
 	"github.com/lmika/shellwords"
 )
 
+// MessagePublisher is used to deliver the messages of commands that are part of a sequence
+type MessagePublisher interface {
+	Send(msg tea.Msg)
+}
+
 type CommandController struct {
 	commandList *CommandContext
+	publisher   MessagePublisher
+	startupFile string
 }
 
 func NewCommandController() *CommandController {
-	return &CommandController{
+	cc := &CommandController{
 		commandList: nil,
 	}
+	cc.AddCommands(&CommandContext{
+		Commands: map[string]Command{
+			"source": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return events.SetError(errors.New("expected filename"))
+				}
+				return cc.ExecuteFile(args[0])
+			},
+			"help": func(args []string) tea.Cmd {
+				return func() tea.Msg {
+					return ShowHelpMsg{Commands: cc.Describe()}
+				}
+			},
+		},
+		Help: map[string]CommandHelp{
+			"source": {
+				Description: "execute the commands in a file",
+				Args:        []ArgSpec{{Name: "filename", Completer: CompleteFiles}},
+			},
+			"help": {Description: "show available commands and key bindings"},
+		},
+	})
+	return cc
+}
+
+// SetPublisher sets the publisher used to deliver the messages of all but the last command in a sequence.
+func (c *CommandController) SetPublisher(publisher MessagePublisher) {
+	c.publisher = publisher
+}
+
+// SetStartupFile sets the file of commands executed by Init.
+func (c *CommandController) SetStartupFile(filename string) {
+	c.startupFile = filename
 }
 
 func (c *CommandController) AddCommands(ctx *CommandContext) {
@@ -25,6 +68,49 @@ func (c *CommandController) AddCommands(ctx *CommandContext) {
 	c.commandList = ctx
 }
 
+// AddMacros adds user defined commands.  Each macro maps a name to a command line, which may contain multiple
+// commands separated by semicolons.  Arguments passed to the macro can be referenced using $1 to $9, or $* for
+// all arguments.
+func (c *CommandController) AddMacros(macros map[string]string) {
+	if len(macros) == 0 {
+		return
+	}
+
+	commands := make(map[string]Command)
+	help := make(map[string]CommandHelp)
+	for name, commandInput := range macros {
+		name, commandInput := name, commandInput
+		commands[name] = func(args []string) tea.Cmd {
+			return c.executeMacro(name, commandInput, args, nil)
+		}
+		help[name] = CommandHelp{Description: "macro: " + commandInput}
+	}
+	c.AddCommands(&CommandContext{Commands: commands, Help: help, macros: macros})
+}
+
+// executeMacro executes the commands of a macro.  Expanding holds the names of the macros currently being
+// expanded, which is used to detect macros which invoke themselves.
+func (c *CommandController) executeMacro(name string, commandInput string, args []string, expanding []string) tea.Cmd {
+	for _, expandingName := range expanding {
+		if expandingName == name {
+			return events.SetError(errors.Errorf("recursive macro: %v -> %v", strings.Join(expanding, " -> "), name))
+		}
+	}
+
+	expanding = append(expanding[:len(expanding):len(expanding)], name)
+	return c.executeAll(splitCommands(expandMacroArgs(commandInput, args)), expanding)
+}
+
+// Init executes the startup file, if one is set and exists.
+func (c *CommandController) Init() tea.Cmd {
+	if c.startupFile == "" {
+		return nil
+	} else if _, err := os.Stat(c.startupFile); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return c.ExecuteFile(c.startupFile)
+}
+
 func (c *CommandController) Prompt() tea.Cmd {
 	return func() tea.Msg {
 		return events.PromptForInputMsg{
@@ -32,17 +118,56 @@ func (c *CommandController) Prompt() tea.Cmd {
 			OnDone: func(value string) tea.Cmd {
 				return c.Execute(value)
 			},
+			Completer: c.Complete,
 		}
 	}
 }
 
+// Execute executes the command input.  The input may contain multiple commands separated by semicolons, which
+// are executed in order.
 func (c *CommandController) Execute(commandInput string) tea.Cmd {
+	return c.executeAll(splitCommands(commandInput), nil)
+}
+
+// ExecuteFile executes the commands within a file.  Each line is executed in order.  Blank lines and lines
+// beginning with '#' are ignored.
+func (c *CommandController) ExecuteFile(filename string) tea.Cmd {
+	commands, err := readCommandFile(filename)
+	if err != nil {
+		return events.SetError(err)
+	}
+	return c.executeAll(commands, nil)
+}
+
+// executeAll executes the commands in order.  Expanding holds the names of the macros the commands were
+// expanded from, if any.
+func (c *CommandController) executeAll(commands []string, expanding []string) tea.Cmd {
+	switch len(commands) {
+	case 0:
+		return nil
+	case 1:
+		return c.executeOne(commands[0], expanding)
+	}
+
+	steps := make([]func() tea.Cmd, len(commands))
+	for i, command := range commands {
+		command := command
+		steps[i] = func() tea.Cmd { return c.executeOne(command, expanding) }
+	}
+	return c.sequence(steps)
+}
+
+func (c *CommandController) executeOne(commandInput string, expanding []string) tea.Cmd {
 	input := strings.TrimSpace(commandInput)
 	if input == "" {
 		return nil
 	}
 
 	tokens := shellwords.Split(input)
+	if macroInput, isMacro := c.lookupMacro(tokens[0]); isMacro {
+		return c.executeMacro(tokens[0], macroInput, tokens[1:], expanding)
+	}
+
 	command := c.lookupCommand(tokens[0])
 	if command == nil {
 		log.Println("No such command: ", tokens)
@@ -52,6 +177,59 @@ func (c *CommandController) Execute(commandInput string) tea.Cmd {
 	return command(tokens[1:])
 }
 
+// Sequence returns a command which runs the passed in commands in order.  The message of each command is
+// delivered before the next one is started.
+func (c *CommandController) Sequence(cmds ...tea.Cmd) tea.Cmd {
+	steps := make([]func() tea.Cmd, len(cmds))
+	for i, cmd := range cmds {
+		cmd := cmd
+		steps[i] = func() tea.Cmd { return cmd }
+	}
+	return c.sequence(steps)
+}
+
+// sequence returns a command which runs each step in order.  The message of each step is delivered before the
+// next step is started.  The sequence will stop if a step returns an error, and will be suspended while
+// the user responds to a prompt.
+func (c *CommandController) sequence(steps []func() tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		for i, step := range steps {
+			cmd := step()
+			if cmd == nil {
+				continue
+			}
+
+			msg := cmd()
+			rest := steps[i+1:]
+
+			switch m := msg.(type) {
+			case nil:
+				continue
+			case events.ErrorMsg:
+				return m
+			case events.PromptForInputMsg:
+				if len(rest) == 0 {
+					return m
+				}
+
+				onDone := m.OnDone
+				m.OnDone = func(value string) tea.Cmd {
+					return c.sequence(append([]func() tea.Cmd{func() tea.Cmd { return onDone(value) }}, rest...))
+				}
+				return m
+			}
+
+			if len(rest) == 0 {
+				return msg
+			} else if c.publisher == nil {
+				return tea.Batch(func() tea.Msg { return msg }, c.sequence(rest))()
+			}
+			c.publisher.Send(msg)
+		}
+		return nil
+	}
+}
+
 func (c *CommandController) Alias(commandName string) Command {
 	return func(args []string) tea.Cmd {
 		command := c.lookupCommand(commandName)
@@ -67,9 +245,110 @@ func (c *CommandController) Alias(commandName string) Command {
 func (c *CommandController) lookupCommand(name string) Command {
 	for ctx := c.commandList; ctx != nil; ctx = ctx.parent {
 		log.Printf("Looking in command list: %v", c.commandList)
-		if cmd, ok := ctx.Commands[name]; ok {
+		if cmd, _, _ := ctx.lookup(name); cmd != nil {
 			return cmd
 		}
 	}
 	return nil
 }
+
+// lookupMacro returns the command input of the macro with the given name, unless the name refers to a command
+// which is not a macro.
+func (c *CommandController) lookupMacro(name string) (string, bool) {
+	for ctx := c.commandList; ctx != nil; ctx = ctx.parent {
+		if cmd, _, _ := ctx.lookup(name); cmd != nil {
+			commandInput, isMacro := ctx.macros[name]
+			return commandInput, isMacro
+		}
+	}
+	return "", false
+}
+
+func readCommandFile(filename string) ([]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open %v", filename)
+	}
+	defer f.Close()
+
+	var commands []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		commands = append(commands, splitCommands(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "cannot read %v", filename)
+	}
+	return commands, nil
+}
+
+// splitCommands splits the input into separate commands on each semicolon that does not appear within quotes.
+func splitCommands(input string) []string {
+	var (
+		commands []string
+		current  strings.Builder
+		quote    rune
+	)
+
+	addCommand := func() {
+		if command := strings.TrimSpace(current.String()); command != "" {
+			commands = append(commands, command)
+		}
+		current.Reset()
+	}
+
+	for _, r := range input {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ';':
+			addCommand()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	addCommand()
+
+	return commands
+}
+
+// expandMacroArgs replaces references to macro arguments with the arguments themselves.
+func expandMacroArgs(commandInput string, args []string) string {
+	return os.Expand(commandInput, func(name string) string {
+		if name == "*" {
+			return strings.Join(quoteArgs(args), " ")
+		}
+
+		n, err := strconv.Atoi(name)
+		if err != nil {
+			return "$" + name
+		} else if n < 1 || n > len(args) {
+			return ""
+		}
+		return quoteArgs(args[n-1 : n])[0]
+	})
+}
+
+// quoteArgs quotes arguments that would otherwise be split or treated as separate commands.
+func quoteArgs(args []string) []string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case arg != "" && !strings.ContainsAny(arg, " \t'\";"):
+			quoted[i] = arg
+		case !strings.Contains(arg, "'"):
+			quoted[i] = "'" + arg + "'"
+		default:
+			quoted[i] = `"` + arg + `"`
+		}
+	}
+	return quoted
+}
@@ -1,8 +1,13 @@
 package commandctrl_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+
 	"github.com/lmika/audax/internal/common/ui/commandctrl"
 	"github.com/lmika/audax/internal/common/ui/events"
 	"github.com/stretchr/testify/assert"
@@ -19,3 +24,173 @@ func TestCommandController_Prompt(t *testing.T) {
 		assert.Equal(t, ":", promptForInputMsg.Prompt)
 	})
 }
+
+func TestCommandController_Execute(t *testing.T) {
+	t.Run("execute commands separated by semicolons in order", func(t *testing.T) {
+		cmd, calls := newTestCommandController()
+		publisher := &testPublisher{}
+		cmd.SetPublisher(publisher)
+
+		res := cmd.Execute(`echo a; echo 'b; c' ; echo d`)()
+
+		assert.Equal(t, []string{"a", "b; c", "d"}, *calls)
+		assert.Equal(t, []tea.Msg{events.StatusMsg("a"), events.StatusMsg("b; c")}, publisher.msgs)
+		assert.Equal(t, events.StatusMsg("d"), res)
+	})
+
+	t.Run("stop executing commands on error", func(t *testing.T) {
+		cmd, calls := newTestCommandController()
+		cmd.SetPublisher(&testPublisher{})
+
+		res := cmd.Execute(`echo a; bogus; echo b`)()
+
+		assert.Equal(t, []string{"a"}, *calls)
+		assert.Error(t, res.(events.ErrorMsg))
+	})
+
+	t.Run("resume executing commands after prompt", func(t *testing.T) {
+		cmd, calls := newTestCommandController()
+		publisher := &testPublisher{}
+		cmd.SetPublisher(publisher)
+
+		res := cmd.Execute(`ask; echo b`)()
+		promptForInputMsg, ok := res.(events.PromptForInputMsg)
+		assert.True(t, ok)
+		assert.Empty(t, *calls)
+
+		res = promptForInputMsg.OnDone("a")()
+		assert.Equal(t, []string{"a", "b"}, *calls)
+		assert.Equal(t, events.StatusMsg("b"), res)
+	})
+}
+
+func TestCommandController_AddMacros(t *testing.T) {
+	cmd, calls := newTestCommandController()
+	cmd.SetPublisher(&testPublisher{})
+	cmd.AddMacros(map[string]string{
+		"twice": "echo $1; echo $1",
+		"all":   "echo $*",
+	})
+
+	cmd.Execute(`twice 'hello world'`)()
+	cmd.Execute(`all a b`)()
+
+	assert.Equal(t, []string{"hello world", "hello world", "a b"}, *calls)
+}
+
+func TestCommandController_AddMacros_Recursive(t *testing.T) {
+	cmd, calls := newTestCommandController()
+	cmd.SetPublisher(&testPublisher{})
+	cmd.AddMacros(map[string]string{
+		"loop":  "echo loop; loop",
+		"ping":  "echo ping; pong",
+		"pong":  "ping",
+		"again": "twice",
+		"twice": "echo a; echo a",
+	})
+
+	res := cmd.Execute("loop")()
+	assert.EqualError(t, res.(events.ErrorMsg), "recursive macro: loop -> loop")
+
+	res = cmd.Execute("ping")()
+	assert.EqualError(t, res.(events.ErrorMsg), "recursive macro: ping -> pong -> ping")
+
+	cmd.Execute("again; again")()
+	assert.Equal(t, []string{"loop", "ping", "a", "a", "a", "a"}, *calls)
+}
+
+func TestCommandController_ExecuteFile(t *testing.T) {
+	t.Run("execute each command in file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "test.rc")
+		assert.NoError(t, os.WriteFile(filename, []byte("# comment\necho a\n\necho b; echo c\n"), 0644))
+
+		cmd, calls := newTestCommandController()
+		cmd.SetPublisher(&testPublisher{})
+
+		cmd.Execute("source " + filename)()
+		assert.Equal(t, []string{"a", "b", "c"}, *calls)
+	})
+
+	t.Run("return error if file does not exist", func(t *testing.T) {
+		cmd, _ := newTestCommandController()
+
+		res := cmd.ExecuteFile(filepath.Join(t.TempDir(), "missing.rc"))()
+		assert.Error(t, res.(events.ErrorMsg))
+	})
+}
+
+func newTestCommandController() (*commandctrl.CommandController, *[]string) {
+	var calls []string
+
+	cmd := commandctrl.NewCommandController()
+	cmd.AddCommands(&commandctrl.CommandContext{
+		Commands: map[string]commandctrl.Command{
+			"echo": func(args []string) tea.Cmd {
+				return func() tea.Msg {
+					msg := strings.Join(args, " ")
+					calls = append(calls, msg)
+					return events.StatusMsg(msg)
+				}
+			},
+			"ask": commandctrl.NoArgCommand(events.PromptForInput("value: ", func(value string) tea.Cmd {
+				return cmd.Execute("echo " + value)
+			})),
+		},
+	})
+	return cmd, &calls
+}
+
+type testPublisher struct {
+	msgs []tea.Msg
+}
+
+func (t *testPublisher) Send(msg tea.Msg) {
+	t.msgs = append(t.msgs, msg)
+}
+
+func TestCommandController_Complete(t *testing.T) {
+	cmd, _ := newTestCommandController()
+	cmd.AddCommands(&commandctrl.CommandContext{
+		Commands: map[string]commandctrl.Command{
+			"table": commandctrl.NoArgCommand(nil),
+		},
+		Help: map[string]commandctrl.CommandHelp{
+			"table": {Args: []commandctrl.ArgSpec{{
+				Name:      "table",
+				Completer: commandctrl.CompleteFrom(func() []string { return []string{"users", "user-events", "orders"} }),
+			}}},
+		},
+	})
+
+	scenarios := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "ta", expected: []string{"table"}},
+		{input: "e", expected: []string{"echo"}},
+		{input: "table user", expected: []string{"table user-events", "table users"}},
+		{input: "table ", expected: []string{"table orders", "table user-events", "table users"}},
+		{input: "echo a; table o", expected: []string{"echo a; table orders"}},
+		{input: "echo ", expected: []string{}},
+		{input: "bogus ", expected: []string{}},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.input, func(t *testing.T) {
+			assert.Equal(t, scenario.expected, cmd.Complete(scenario.input))
+		})
+	}
+}
+
+func TestCommandController_Describe(t *testing.T) {
+	cmd, _ := newTestCommandController()
+
+	descriptions := cmd.Describe()
+
+	assert.Contains(t, descriptions, commandctrl.CommandDescription{
+		Name:        "source",
+		Usage:       "<filename>",
+		Description: "execute the commands in a file",
+	})
+	assert.Contains(t, descriptions, commandctrl.CommandDescription{Name: "echo"})
+}
@@ -0,0 +1,136 @@
+package commandctrl
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Complete returns the possible completions of the command input.  Each completion is the input with the last
+// word completed.  Command names are completed for the first word, otherwise the completer of the command's
+// argument is used.
+func (c *CommandController) Complete(input string) []string {
+	words := strings.Fields(input[lastCommandStart(input):])
+	if len(words) == 0 || strings.HasSuffix(input, " ") {
+		words = append(words, "")
+	}
+	lastWord := words[len(words)-1]
+	inputPrefix := input[:len(input)-len(lastWord)]
+
+	var candidates []string
+	if len(words) == 1 {
+		candidates = completeFrom(c.commandNames(), lastWord)
+	} else if help, ok := c.lookupHelp(words[0]); ok && len(help.Args) > 0 {
+		argSpec := help.Args[min(len(words)-2, len(help.Args)-1)]
+		if argSpec.Completer != nil {
+			candidates = argSpec.Completer(lastWord)
+		}
+	}
+
+	completions := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		completions[i] = inputPrefix + candidate
+	}
+	return completions
+}
+
+// Describe returns a description of all the available commands, sorted by name.
+func (c *CommandController) Describe() []CommandDescription {
+	names := c.commandNames()
+	descriptions := make([]CommandDescription, len(names))
+	for i, name := range names {
+		help, _ := c.lookupHelp(name)
+		descriptions[i] = CommandDescription{Name: name, Usage: help.Usage(), Description: help.Description}
+	}
+	return descriptions
+}
+
+func (c *CommandController) commandNames() []string {
+	seenNames := make(map[string]bool)
+	names := make([]string, 0)
+	for ctx := c.commandList; ctx != nil; ctx = ctx.parent {
+		for _, name := range ctx.names() {
+			if !seenNames[name] {
+				seenNames[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *CommandController) lookupHelp(name string) (CommandHelp, bool) {
+	for ctx := c.commandList; ctx != nil; ctx = ctx.parent {
+		if cmd, help, hasHelp := ctx.lookup(name); cmd != nil {
+			return help, hasHelp
+		}
+	}
+	return CommandHelp{}, false
+}
+
+// lastCommandStart returns the index of the start of the last command in a sequence.
+func lastCommandStart(input string) int {
+	var (
+		start int
+		quote rune
+	)
+	for i, r := range input {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ';':
+			start = i + 1
+		}
+	}
+	return start
+}
+
+// CompleteFrom returns a completer which will complete from the values returned by the passed in function.
+func CompleteFrom(values func() []string) Completer {
+	return func(prefix string) []string {
+		return completeFrom(values(), prefix)
+	}
+}
+
+// CompleteFiles is a completer which completes file paths.  Directories are completed with a trailing separator.
+func CompleteFiles(prefix string) []string {
+	matches, err := filepath.Glob(prefix + "*")
+	if err != nil {
+		return nil
+	}
+
+	for i, match := range matches {
+		if strings.HasPrefix(prefix, "./") && !strings.HasPrefix(match, "./") {
+			match = "./" + match
+		}
+		if info, err := os.Stat(match); err == nil && info.IsDir() {
+			match += string(filepath.Separator)
+		}
+		matches[i] = match
+	}
+	return matches
+}
+
+func completeFrom(values []string, prefix string) []string {
+	completions := make([]string, 0)
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			completions = append(completions, v)
+		}
+	}
+	sort.Strings(completions)
+	return completions
+}
+
+func min(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
@@ -1,6 +1,10 @@
 package commandctrl
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+	"sync"
+)
 
 type Command func(args []string) tea.Cmd
 
@@ -10,8 +14,102 @@ func NoArgCommand(cmd tea.Cmd) Command {
 	}
 }
 
+// Completer returns the possible completions of an argument starting with prefix
+type Completer func(prefix string) []string
+
+// ArgSpec describes an argument of a command
+type ArgSpec struct {
+	Name      string
+	Optional  bool
+	Completer Completer
+}
+
+// CommandHelp describes a command and its arguments.  The completer of the last argument is used for any
+// arguments beyond those described.
+type CommandHelp struct {
+	Description string
+	Args        []ArgSpec
+}
+
+// Usage returns the arguments of the command, such as "<name> [source]"
+func (ch CommandHelp) Usage() string {
+	usage := make([]string, len(ch.Args))
+	for i, arg := range ch.Args {
+		if arg.Optional {
+			usage[i] = "[" + arg.Name + "]"
+		} else {
+			usage[i] = "<" + arg.Name + ">"
+		}
+	}
+	return strings.Join(usage, " ")
+}
+
 type CommandContext struct {
 	Commands map[string]Command
 
-	parent   *CommandContext
+	// Help describes the commands of this context.  Commands without help can still be executed and completed.
+	Help map[string]CommandHelp
+
+	// mutex guards Commands and Help, so that commands can be changed using SetCommand and RemoveCommand while
+	// the context is in use
+	mutex  sync.RWMutex
+	macros map[string]string
+	parent *CommandContext
+}
+
+// SetCommand adds or replaces a command of the context, along with its help.
+func (cc *CommandContext) SetCommand(name string, command Command, help CommandHelp) {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+
+	if cc.Commands == nil {
+		cc.Commands = make(map[string]Command)
+	}
+	if cc.Help == nil {
+		cc.Help = make(map[string]CommandHelp)
+	}
+	cc.Commands[name] = command
+	cc.Help[name] = help
+}
+
+// RemoveCommand removes a command from the context.
+func (cc *CommandContext) RemoveCommand(name string) {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+
+	delete(cc.Commands, name)
+	delete(cc.Help, name)
+}
+
+// lookup returns the command with the given name, along with its help if it has any.
+func (cc *CommandContext) lookup(name string) (command Command, help CommandHelp, hasHelp bool) {
+	cc.mutex.RLock()
+	defer cc.mutex.RUnlock()
+
+	command = cc.Commands[name]
+	help, hasHelp = cc.Help[name]
+	return command, help, hasHelp
+}
+
+func (cc *CommandContext) names() []string {
+	cc.mutex.RLock()
+	defer cc.mutex.RUnlock()
+
+	names := make([]string, 0, len(cc.Commands))
+	for name := range cc.Commands {
+		names = append(names, name)
+	}
+	return names
+}
+
+// CommandDescription describes a command for display in help
+type CommandDescription struct {
+	Name        string
+	Usage       string
+	Description string
+}
+
+// ShowHelpMsg requests that help for the available commands is shown
+type ShowHelpMsg struct {
+	Commands []CommandDescription
 }
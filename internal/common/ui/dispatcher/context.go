@@ -1,7 +1,10 @@
 package dispatcher
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/events"
 	"github.com/lmika/audax/internal/common/ui/uimodels"
 )
 
@@ -10,15 +13,15 @@ type DispatcherContext struct {
 }
 
 func (dc DispatcherContext) Messagef(format string, args ...interface{}) {
-	// dc.Publisher.Send(events.Message(fmt.Sprintf(format, args...)))
+	dc.Publisher.Send(events.StatusMsg(fmt.Sprintf(format, args...)))
 }
 
 func (dc DispatcherContext) Send(teaMessage tea.Msg) {
-	// dc.Publisher.Send(teaMessage)
+	dc.Publisher.Send(teaMessage)
 }
 
 func (dc DispatcherContext) Message(msg string) {
-	// dc.Publisher.Send(events.Message(msg))
+	dc.Publisher.Send(events.StatusMsg(msg))
 }
 
 func (dc DispatcherContext) Input(prompt string, onDone uimodels.Operation) {
@@ -22,6 +22,12 @@ func SetStatus(msg string) tea.Cmd {
 	}
 }
 
+func SetStatusContext(msg string) tea.Cmd {
+	return func() tea.Msg {
+		return StatusContextMsg(msg)
+	}
+}
+
 func PromptForInput(prompt string, onDone func(value string) tea.Cmd) tea.Cmd {
 	return func() tea.Msg {
 		return PromptForInputMsg{
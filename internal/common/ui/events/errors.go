@@ -13,8 +13,27 @@ type StatusMsg string
 // ModeMessage indicates that the mode should be changed to the following
 type ModeMessage string
 
+// StatusContextMsg indicates that the context displayed on the right of the mode line should be changed
+type StatusContextMsg string
+
 // PromptForInput indicates that the context is requesting a line of input
 type PromptForInputMsg struct {
 	Prompt string
 	OnDone func(value string) tea.Cmd
+
+	// InitialValue, if set, is the value the input starts with
+	InitialValue string
+
+	// OnCancel, if set, is called when the user cancels the prompt
+	OnCancel func() tea.Cmd
+
+	// OnChange, if set, is called whenever the user changes the input, allowing for incremental updates
+	OnChange func(value string) tea.Cmd
+
+	// History is a list of previously entered values, oldest first, that can be recalled using the up and down keys
+	History []string
+
+	// Completer returns the possible completions of the input when the tab key is pressed.  Each completion
+	// replaces the entire input.
+	Completer func(input string) []string
 }
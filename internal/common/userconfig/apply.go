@@ -0,0 +1,129 @@
+package userconfig
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pkg/errors"
+)
+
+var (
+	keyBindingType = reflect.TypeOf(key.Binding{})
+	styleType      = reflect.TypeOf(lipgloss.Style{})
+)
+
+// ApplyKeyBindings rebinds the key.Binding fields of the struct pointed to by target.  Fields are named using
+// the "config" tag, with nested structs joined by dots, e.g. "table.move-up".  An error is returned if a binding
+// is not recognised.
+func ApplyKeyBindings(target any, keys map[string][]string) error {
+	fields := collectFields(target, keyBindingType)
+
+	for name, keyNames := range keys {
+		field, ok := fields[name]
+		if !ok {
+			return errors.Errorf("unrecognised key binding: %v", name)
+		}
+
+		binding := field.Addr().Interface().(*key.Binding)
+		binding.SetKeys(keyNames...)
+		if help := binding.Help(); help.Desc != "" {
+			binding.SetHelp(strings.Join(keyNames, "/"), help.Desc)
+		}
+	}
+	return nil
+}
+
+// ApplyStyles overrides the lipgloss.Style fields of the struct pointed to by target.  Fields are named in the
+// same way as ApplyKeyBindings.
+func ApplyStyles(target any, styles map[string]StyleConfig) error {
+	fields := collectFields(target, styleType)
+
+	for name, styleConfig := range styles {
+		field, ok := fields[name]
+		if !ok {
+			return errors.Errorf("unrecognised style: %v", name)
+		}
+
+		style := field.Addr().Interface().(*lipgloss.Style)
+		*style = styleConfig.apply(style.Copy())
+	}
+	return nil
+}
+
+func (sc StyleConfig) apply(style lipgloss.Style) lipgloss.Style {
+	if sc.Foreground != "" {
+		style = style.Foreground(lipgloss.Color(sc.Foreground))
+	}
+	if sc.Background != "" {
+		style = style.Background(lipgloss.Color(sc.Background))
+	}
+	if sc.Bold != nil {
+		style = style.Bold(*sc.Bold)
+	}
+	if sc.Italic != nil {
+		style = style.Italic(*sc.Italic)
+	}
+	if sc.Underline != nil {
+		style = style.Underline(*sc.Underline)
+	}
+	return style
+}
+
+// BindingDescription describes a key binding, for display in help
+type BindingDescription struct {
+	Name string
+	Keys []string
+}
+
+// DescribeKeyBindings returns a description of each key.Binding field of the struct pointed to by target, in the
+// order they are declared.  Disabled bindings are excluded.
+func DescribeKeyBindings(target any) []BindingDescription {
+	var descriptions []BindingDescription
+	walkFields(reflect.ValueOf(target), "", keyBindingType, func(name string, field reflect.Value) {
+		binding := field.Interface().(key.Binding)
+		if binding.Enabled() {
+			descriptions = append(descriptions, BindingDescription{Name: name, Keys: binding.Keys()})
+		}
+	})
+	return descriptions
+}
+
+func collectFields(target any, fieldType reflect.Type) map[string]reflect.Value {
+	fields := make(map[string]reflect.Value)
+	walkFields(reflect.ValueOf(target), "", fieldType, func(name string, field reflect.Value) {
+		fields[name] = field
+	})
+	return fields
+}
+
+// walkFields calls fn for each field of type fieldType with a "config" tag, descending into nested structs.
+func walkFields(v reflect.Value, prefix string, fieldType reflect.Type, fn func(name string, field reflect.Value)) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		name, ok := v.Type().Field(i).Tag.Lookup("config")
+		if !ok {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		field := v.Field(i)
+		if field.Type() == fieldType {
+			fn(name, field)
+		} else {
+			walkFields(field, name, fieldType, fn)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package userconfig
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/lmika/audax/internal/common/awsconfig"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const configFilename = "config.yaml"
+
+// Config is the user configuration shared by all tools.  It is read from "audax/config.yaml" within the
+// user's config directory.
+type Config struct {
+	AWS AWSConfig `yaml:"aws"`
+
+	DynamoBrowse ToolConfig `yaml:"dynamo-browse"`
+	SSMBrowse    ToolConfig `yaml:"ssm-browse"`
+	SQSBrowse    ToolConfig `yaml:"sqs-browse"`
+	SQSDrain     ToolConfig `yaml:"sqs-drain"`
+	SlogView     ToolConfig `yaml:"slog-view"`
+}
+
+// AWSConfig are the default AWS settings used when not specified on the command line
+type AWSConfig struct {
+	Profile string `yaml:"profile"`
+	Region  string `yaml:"region"`
+}
+
+// ToolConfig is configuration specific to a particular tool
+type ToolConfig struct {
+	// Endpoint is the URL of the AWS endpoint to use in place of the default, such as a localstack instance
+	Endpoint string `yaml:"endpoint"`
+
+	// ScanLimit is the default maximum number of items to retrieve
+	ScanLimit int `yaml:"scan-limit"`
+
+	// Keys maps bindings, such as "table.move-up", to the keys that will trigger them
+	Keys map[string][]string `yaml:"keys"`
+
+	// Styles maps style names, such as "frames.active-title", to overrides of that style
+	Styles map[string]StyleConfig `yaml:"styles"`
+
+	// Macros maps user defined command names to the commands they will execute
+	Macros map[string]string `yaml:"macros"`
+
+	// Scripts are the script files loaded on startup
+	Scripts []string `yaml:"scripts"`
+}
+
+type StyleConfig struct {
+	Foreground string `yaml:"foreground"`
+	Background string `yaml:"background"`
+	Bold       *bool  `yaml:"bold"`
+	Italic     *bool  `yaml:"italic"`
+	Underline  *bool  `yaml:"underline"`
+}
+
+// DefaultPath returns the path of the user's config file.
+func DefaultPath() (string, error) {
+	return configPath(configFilename)
+}
+
+// StartupFile returns the path of the file of commands executed when the named tool starts, such as
+// "audax/dynamo-browse.rc" within the user's config directory.
+func StartupFile(toolName string) (string, error) {
+	return configPath(toolName + ".rc")
+}
+
+func configPath(filename string) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errors.Wrap(err, "cannot determine user config dir")
+	}
+	return filepath.Join(configDir, "audax", filename), nil
+}
+
+// Load loads the user config from the default path.  If the file does not exist, an empty config is returned.
+func Load() (*Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile loads the user config from the passed in file.  If the file does not exist, an empty config is returned.
+func LoadFile(path string) (*Config, error) {
+	var cfg Config
+
+	bts, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &cfg, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "cannot read config file %v", path)
+	}
+
+	if err := yaml.Unmarshal(bts, &cfg); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse config file %v", path)
+	}
+	return &cfg, nil
+}
+
+// ApplyAWSDefaults sets the profile and region of opts from the config if they have not already been set.
+func (c *Config) ApplyAWSDefaults(opts *awsconfig.Options) {
+	if opts.Profile == "" {
+		opts.Profile = c.AWS.Profile
+	}
+	if opts.Region == "" {
+		opts.Region = c.AWS.Region
+	}
+}
@@ -0,0 +1,107 @@
+package userconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lmika/audax/internal/common/awsconfig"
+	"github.com/lmika/audax/internal/common/userconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFile(t *testing.T) {
+	t.Run("should load config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(`
+aws:
+  profile: dev
+dynamo-browse:
+  endpoint: http://localhost:8000
+  scan-limit: 50
+  keys:
+    table.move-up: [w]
+  styles:
+    frames.active-title:
+      background: "#ff0000"
+`), 0644))
+
+		cfg, err := userconfig.LoadFile(path)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "dev", cfg.AWS.Profile)
+		assert.Equal(t, "http://localhost:8000", cfg.DynamoBrowse.Endpoint)
+		assert.Equal(t, 50, cfg.DynamoBrowse.ScanLimit)
+		assert.Equal(t, []string{"w"}, cfg.DynamoBrowse.Keys["table.move-up"])
+		assert.Equal(t, "#ff0000", cfg.DynamoBrowse.Styles["frames.active-title"].Background)
+	})
+
+	t.Run("should return empty config if file does not exist", func(t *testing.T) {
+		cfg, err := userconfig.LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+		assert.NoError(t, err)
+		assert.Equal(t, &userconfig.Config{}, cfg)
+	})
+}
+
+func TestConfig_ApplyAWSDefaults(t *testing.T) {
+	cfg := &userconfig.Config{AWS: userconfig.AWSConfig{Profile: "dev", Region: "us-east-1"}}
+
+	opts := awsconfig.Options{Region: "ap-southeast-2"}
+	cfg.ApplyAWSDefaults(&opts)
+
+	assert.Equal(t, "dev", opts.Profile)
+	assert.Equal(t, "ap-southeast-2", opts.Region)
+}
+
+func TestApplyKeyBindings(t *testing.T) {
+	type tableKeys struct {
+		MoveUp key.Binding `config:"move-up"`
+	}
+	type keyBindings struct {
+		Table *tableKeys  `config:"table"`
+		Quit  key.Binding `config:"quit"`
+	}
+
+	t.Run("should rebind keys", func(t *testing.T) {
+		kb := &keyBindings{
+			Table: &tableKeys{MoveUp: key.NewBinding(key.WithKeys("i"))},
+			Quit:  key.NewBinding(key.WithKeys("q")),
+		}
+
+		err := userconfig.ApplyKeyBindings(kb, map[string][]string{
+			"table.move-up": {"w", "up"},
+			"quit":          {"ctrl+q"},
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"w", "up"}, kb.Table.MoveUp.Keys())
+		assert.Equal(t, []string{"ctrl+q"}, kb.Quit.Keys())
+	})
+
+	t.Run("should return error on unrecognised binding", func(t *testing.T) {
+		kb := &keyBindings{Table: &tableKeys{}}
+
+		err := userconfig.ApplyKeyBindings(kb, map[string][]string{"table.bogus": {"x"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestApplyStyles(t *testing.T) {
+	type styles struct {
+		Title lipgloss.Style `config:"title"`
+	}
+
+	s := styles{Title: lipgloss.NewStyle().Foreground(lipgloss.Color("#000000"))}
+	bold := true
+
+	err := userconfig.ApplyStyles(&s, map[string]userconfig.StyleConfig{
+		"title": {Background: "#ffffff", Bold: &bold},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, lipgloss.Color("#000000"), s.Title.GetForeground())
+	assert.Equal(t, lipgloss.Color("#ffffff"), s.Title.GetBackground())
+	assert.True(t, s.Title.GetBold())
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/awsconfig"
+	"github.com/lmika/audax/internal/common/ui/events"
+)
+
+type ConnectionController struct {
+	connector           Connector
+	tableReadController *TableReadController
+
+	// state
+	mutex   *sync.Mutex
+	options awsconfig.Options
+}
+
+func NewConnectionController(connector Connector, tableReadController *TableReadController, initialOptions awsconfig.Options) *ConnectionController {
+	return &ConnectionController{
+		connector:           connector,
+		tableReadController: tableReadController,
+		mutex:               new(sync.Mutex),
+		options:             initialOptions,
+	}
+}
+
+// Init returns the current connection details so that they can be displayed to the user.
+func (c *ConnectionController) Init() tea.Cmd {
+	return events.SetStatusContext(c.Options().String())
+}
+
+func (c *ConnectionController) Options() awsconfig.Options {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.options
+}
+
+// SwitchProfile reconnects using the passed in profile.  The region will be reset to the default region of the profile.
+func (c *ConnectionController) SwitchProfile(profile string) tea.Cmd {
+	opts := c.Options()
+	opts.Profile = profile
+	opts.Region = ""
+	return c.connect(opts)
+}
+
+// SwitchRegion reconnects using the passed in region.
+func (c *ConnectionController) SwitchRegion(region string) tea.Cmd {
+	opts := c.Options()
+	opts.Region = region
+	return c.connect(opts)
+}
+
+func (c *ConnectionController) connect(opts awsconfig.Options) tea.Cmd {
+	return c.tableReadController.doIfNoneDirty(func() tea.Msg {
+		newOpts, err := c.connector.Connect(context.Background(), opts)
+		if err != nil {
+			return events.Error(err)
+		}
+
+		c.mutex.Lock()
+		c.options = newOpts
+		c.mutex.Unlock()
+
+		return tea.Batch(
+			events.SetStatusContext(newOpts.String()),
+			c.tableReadController.ListTables(),
+		)()
+	})
+}
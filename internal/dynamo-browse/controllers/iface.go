@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"context"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/awsconfig"
 	"github.com/lmika/audax/internal/dynamo-browse/models"
 )
 
@@ -10,5 +12,16 @@ type TableReadService interface {
 	Describe(ctx context.Context, table string) (*models.TableInfo, error)
 	Scan(ctx context.Context, tableInfo *models.TableInfo) (*models.ResultSet, error)
 	Filter(resultSet *models.ResultSet, filter string) *models.ResultSet
+	ExecuteStatement(ctx context.Context, stmt *models.PartiQLStatement) (*models.ResultSet, int, error)
 	ScanOrQuery(ctx context.Context, tableInfo *models.TableInfo, query models.Queryable) (*models.ResultSet, error)
 }
+
+// MessagePublisher delivers messages to the running program
+type MessagePublisher interface {
+	Send(msg tea.Msg)
+}
+
+type Connector interface {
+	// Connect reconfigures the AWS clients using the passed in options, returning the options actually in effect.
+	Connect(ctx context.Context, opts awsconfig.Options) (awsconfig.Options, error)
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/lmika/audax/internal/dynamo-browse/services/savedqueries"
+	"github.com/pkg/errors"
+)
+
+type SavedQueryController struct {
+	state               *State
+	savedQueryService   *savedqueries.Service
+	tableReadController *TableReadController
+}
+
+func NewSavedQueryController(state *State, savedQueryService *savedqueries.Service, tableReadController *TableReadController) *SavedQueryController {
+	return &SavedQueryController{
+		state:               state,
+		savedQueryService:   savedQueryService,
+		tableReadController: tableReadController,
+	}
+}
+
+// PromptForQuery prompts the user for a query, with previously entered queries available for recall.
+func (c *SavedQueryController) PromptForQuery() tea.Cmd {
+	return func() tea.Msg {
+		history, err := c.savedQueryService.History(context.Background())
+		if err != nil {
+			log.Printf("warn: cannot read query history: %v", err)
+		}
+
+		return events.PromptForInputMsg{
+			Prompt:  "query: ",
+			History: history,
+			OnDone: func(value string) tea.Cmd {
+				if err := c.savedQueryService.AddToHistory(context.Background(), value); err != nil {
+					log.Printf("warn: cannot add query to history: %v", err)
+				}
+				return c.tableReadController.RunQuery(value)
+			},
+		}
+	}
+}
+
+// SaveQuery saves the query of the current result set under the passed in name.
+func (c *SavedQueryController) SaveQuery(name string) tea.Cmd {
+	return func() tea.Msg {
+		resultSet := c.state.ResultSet()
+		if resultSet == nil {
+			return events.Error(errors.New("no table selected"))
+		} else if resultSet.Query == nil {
+			return events.Error(errors.New("no query to save"))
+		}
+
+		_, isPartiQL := resultSet.Query.(*models.PartiQLStatement)
+		if err := c.savedQueryService.Save(context.Background(), models.SavedQuery{
+			Name:      name,
+			TableName: resultSet.TableInfo.Name,
+			Query:     resultSet.Query.String(),
+			IsPartiQL: isPartiQL,
+		}); err != nil {
+			return events.Error(err)
+		}
+		return events.StatusMsg("query saved as '" + name + "'")
+	}
+}
+
+// RunSavedQuery runs the saved query with the passed in name against the current table.
+func (c *SavedQueryController) RunSavedQuery(name string) tea.Cmd {
+	return func() tea.Msg {
+		resultSet := c.state.ResultSet()
+		if resultSet == nil {
+			return events.Error(errors.New("no table selected"))
+		}
+
+		savedQuery, err := c.savedQueryService.Lookup(context.Background(), resultSet.TableInfo.Name, name)
+		if err != nil {
+			return events.Error(err)
+		}
+
+		if savedQuery.IsPartiQL {
+			return c.tableReadController.RunStatement(savedQuery.Query)()
+		}
+		return c.tableReadController.RunQuery(savedQuery.Query)()
+	}
+}
+
+// ListSavedQueries displays the names of the saved queries of the current table.
+func (c *SavedQueryController) ListSavedQueries() tea.Cmd {
+	return func() tea.Msg {
+		resultSet := c.state.ResultSet()
+		if resultSet == nil {
+			return events.Error(errors.New("no table selected"))
+		}
+
+		savedQueries, err := c.savedQueryService.List(context.Background(), resultSet.TableInfo.Name)
+		if err != nil {
+			return events.Error(err)
+		} else if len(savedQueries) == 0 {
+			return events.StatusMsg("no saved queries for " + resultSet.TableInfo.Name)
+		}
+
+		names := make([]string, len(savedQueries))
+		for i, q := range savedQueries {
+			names[i] = q.Name
+		}
+		return events.StatusMsg("saved queries: " + strings.Join(names, ", "))
+	}
+}
+
+// SavedQueryNames returns the names of the saved queries of the current table.
+func (c *SavedQueryController) SavedQueryNames() []string {
+	resultSet := c.state.ResultSet()
+	if resultSet == nil {
+		return nil
+	}
+
+	savedQueries, err := c.savedQueryService.List(context.Background(), resultSet.TableInfo.Name)
+	if err != nil {
+		log.Printf("cannot list saved queries: %v", err)
+		return nil
+	}
+
+	names := make([]string, len(savedQueries))
+	for i, q := range savedQueries {
+		names[i] = q.Name
+	}
+	return names
+}
+
+// DeleteSavedQuery deletes the saved query of the current table.
+func (c *SavedQueryController) DeleteSavedQuery(name string) tea.Cmd {
+	return func() tea.Msg {
+		resultSet := c.state.ResultSet()
+		if resultSet == nil {
+			return events.Error(errors.New("no table selected"))
+		}
+
+		if err := c.savedQueryService.Delete(context.Background(), resultSet.TableInfo.Name, name); err != nil {
+			return events.Error(err)
+		}
+		return events.StatusMsg("saved query '" + name + "' deleted")
+	}
+}
@@ -0,0 +1,369 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/commandctrl"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/pkg/errors"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// ScriptController loads Lua scripts which can register new commands.  Scripts have access to the
+// following globals:
+//
+//	audax.command(name, fn [, description])  register a command; fn is called with the command arguments
+//	audax.b64decode(s), audax.b64encode(s)  base64 helpers
+//	audax.binary(s)                          a binary value holding the bytes of s; use tostring(b) to read them
+//	session.result_set()                     the current result set: {table_name, columns, items}
+//	session.selected_item()                  the selected item, or nil
+//	session.selected_index()                 the index of the selected item, or nil
+//	session.scan(table), session.query(expr), session.filter(expr)
+//	session.set_attr(index, name, value), session.delete_attr(index, name), session.put()
+//	ui.status(msg)                           display a status message
+//	ui.prompt(msg)                           prompt for input, returning nil if cancelled or unable to be shown
+//
+// Numbers which cannot be held as Lua numbers without losing precision are decimals, which can be read as strings
+// using tostring().  Sets are lists with a metatable marking the type of set, so that they are written back as sets.
+type ScriptController struct {
+	state                 *State
+	tableReadController   *TableReadController
+	tableWriteController  *TableWriteController
+	publisher             MessagePublisher
+	selectedItemIndexFunc func() int
+	commandCtx            *commandctrl.CommandContext
+
+	// mutex guards the loaded scripts, and the script which registered each command
+	mutex          *sync.Mutex
+	scripts        map[string]*luaScript
+	commandScripts map[string]*luaScript
+}
+
+func NewScriptController(state *State, tableReadController *TableReadController, tableWriteController *TableWriteController) *ScriptController {
+	return &ScriptController{
+		state:                state,
+		tableReadController:  tableReadController,
+		tableWriteController: tableWriteController,
+		commandCtx: &commandctrl.CommandContext{
+			Commands: map[string]commandctrl.Command{},
+			Help:     map[string]commandctrl.CommandHelp{},
+		},
+		mutex:          new(sync.Mutex),
+		scripts:        make(map[string]*luaScript),
+		commandScripts: make(map[string]*luaScript),
+	}
+}
+
+// SetPublisher sets the publisher used to deliver messages while a script is running.
+func (sc *ScriptController) SetPublisher(publisher MessagePublisher) {
+	sc.publisher = publisher
+}
+
+// SetSelectedItemIndexFunc sets the function used to determine the selected item when a command is invoked.
+func (sc *ScriptController) SetSelectedItemIndexFunc(fn func() int) {
+	sc.selectedItemIndexFunc = fn
+}
+
+// CommandContext returns the command context holding the commands registered by scripts.
+func (sc *ScriptController) CommandContext() *commandctrl.CommandContext {
+	return sc.commandCtx
+}
+
+// LoadScript loads the script, registering any commands it defines.  Scripts can only register commands when
+// loaded, so this is done synchronously.  Commands registered by a script loaded earlier will be replaced.  If the
+// script was loaded before, the commands it no longer defines are removed and the earlier script is closed.
+func (sc *ScriptController) LoadScript(filename string) error {
+	if absFilename, err := filepath.Abs(filename); err == nil {
+		filename = absFilename
+	}
+
+	script := &luaScript{controller: sc, L: lua.NewState()}
+	script.registerGlobals()
+
+	script.loading = true
+	err := script.L.DoFile(filename)
+	script.loading = false
+
+	if err != nil {
+		sc.unregisterCommands(script)
+		script.L.Close()
+		return errors.Wrapf(err, "cannot load script %v", filepath.Base(filename))
+	}
+
+	sc.mutex.Lock()
+	oldScript := sc.scripts[filename]
+	sc.scripts[filename] = script
+	sc.mutex.Unlock()
+
+	if oldScript != nil {
+		sc.unregisterCommands(oldScript)
+		go oldScript.close()
+	}
+	return nil
+}
+
+// LoadScriptCmd loads a script and reports the commands registered.
+func (sc *ScriptController) LoadScriptCmd(filename string) tea.Cmd {
+	before := sc.commandCount()
+	if err := sc.LoadScript(filename); err != nil {
+		return events.SetError(err)
+	}
+	return events.SetStatus(applyToN("loaded script: ", sc.commandCount()-before, "new command", "new commands", ""))
+}
+
+func (sc *ScriptController) commandCount() int {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return len(sc.commandScripts)
+}
+
+func (sc *ScriptController) registerCommand(script *luaScript, name string, fn *lua.LFunction, description string) {
+	sc.mutex.Lock()
+	sc.commandScripts[name] = script
+	sc.mutex.Unlock()
+
+	sc.commandCtx.SetCommand(name, func(args []string) tea.Cmd {
+		selectedIdx := -1
+		if sc.selectedItemIndexFunc != nil {
+			selectedIdx = sc.selectedItemIndexFunc()
+		}
+
+		return func() tea.Msg {
+			return script.run(fn, args, selectedIdx)
+		}
+	}, commandctrl.CommandHelp{Description: description})
+}
+
+// unregisterCommands removes the commands registered by the script which have not since been replaced.
+func (sc *ScriptController) unregisterCommands(script *luaScript) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	for name, commandScript := range sc.commandScripts {
+		if commandScript == script {
+			delete(sc.commandScripts, name)
+			sc.commandCtx.RemoveCommand(name)
+		}
+	}
+}
+
+// luaScript is a single loaded script.  Lua states are not safe for concurrent use, so only one command of the
+// script can run at a time.
+type luaScript struct {
+	controller *ScriptController
+	L          *lua.LState
+
+	mutex       sync.Mutex
+	loading     bool
+	closed      bool
+	selectedIdx int
+}
+
+// close closes the Lua state once any running command has finished.  This can block, so should be called from a
+// separate goroutine.
+func (s *luaScript) close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	s.L.Close()
+}
+
+func (s *luaScript) run(fn *lua.LFunction, args []string, selectedIdx int) tea.Msg {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return events.Error(errors.New("script has been reloaded"))
+	}
+	s.selectedIdx = selectedIdx
+
+	luaArgs := make([]lua.LValue, len(args))
+	for i, arg := range args {
+		luaArgs[i] = lua.LString(arg)
+	}
+
+	if err := s.L.CallByParam(lua.P{Fn: fn, NRet: 0, Protect: true}, luaArgs...); err != nil {
+		return events.Error(errors.Wrap(err, "script error"))
+	}
+	return nil
+}
+
+func (s *luaScript) registerGlobals() {
+	L := s.L
+	registerBinaryType(L)
+	registerDecimalType(L)
+	registerSetTypes(L)
+
+	audax := L.NewTable()
+	L.SetFuncs(audax, map[string]lua.LGFunction{
+		"command":   s.command,
+		"b64decode": b64decode,
+		"b64encode": b64encode,
+		"binary": func(L *lua.LState) int {
+			L.Push(binaryToLua(L, []byte(L.CheckString(1))))
+			return 1
+		},
+	})
+	L.SetGlobal("audax", audax)
+
+	session := L.NewTable()
+	L.SetFuncs(session, map[string]lua.LGFunction{
+		"result_set":     s.whenRunning(s.resultSet),
+		"selected_item":  s.whenRunning(s.selectedItem),
+		"selected_index": s.whenRunning(s.selectedIndex),
+		"scan": s.whenRunning(func(L *lua.LState) int {
+			return s.runCmd(L, s.controller.tableReadController.ScanTable(L.CheckString(1)))
+		}),
+		"query": s.whenRunning(func(L *lua.LState) int {
+			return s.runCmd(L, s.controller.tableReadController.RunQuery(L.OptString(1, "")))
+		}),
+		"filter": s.whenRunning(func(L *lua.LState) int {
+			return s.runCmd(L, s.controller.tableReadController.SetFilter(L.OptString(1, "")))
+		}),
+		"set_attr": s.whenRunning(func(L *lua.LState) int {
+			idx, name := L.CheckInt(1)-1, L.CheckString(2)
+			value, err := luaToAttributeValue(L.CheckAny(3))
+			if err != nil {
+				L.RaiseError("%v", err)
+			}
+			return s.runCmd(L, s.controller.tableWriteController.SetItemAttribute(idx, name, value))
+		}),
+		"delete_attr": s.whenRunning(func(L *lua.LState) int {
+			return s.runCmd(L, s.controller.tableWriteController.DeleteAttribute(L.CheckInt(1)-1, L.CheckString(2)))
+		}),
+		"put": s.whenRunning(func(L *lua.LState) int {
+			return s.runCmd(L, s.controller.tableWriteController.PutItems())
+		}),
+	})
+	L.SetGlobal("session", session)
+
+	ui := L.NewTable()
+	L.SetFuncs(ui, map[string]lua.LGFunction{
+		"status": s.whenRunning(func(L *lua.LState) int {
+			s.controller.publisher.Send(events.StatusMsg(L.CheckString(1)))
+			return 0
+		}),
+		"prompt": s.whenRunning(s.prompt),
+	})
+	L.SetGlobal("ui", ui)
+}
+
+// whenRunning guards functions which interact with the session so they can only be called from commands
+func (s *luaScript) whenRunning(fn lua.LGFunction) lua.LGFunction {
+	return func(L *lua.LState) int {
+		if s.loading || s.controller.publisher == nil {
+			L.RaiseError("only available within commands")
+		}
+		return fn(L)
+	}
+}
+
+func (s *luaScript) command(L *lua.LState) int {
+	if !s.loading {
+		L.RaiseError("commands can only be registered when the script is loaded")
+	}
+
+	name := L.CheckString(1)
+	fn := L.CheckFunction(2)
+	description := L.OptString(3, "")
+
+	s.controller.registerCommand(s, name, fn, description)
+	return 0
+}
+
+func (s *luaScript) resultSet(L *lua.LState) int {
+	resultSet := s.controller.state.ResultSet()
+	if resultSet == nil {
+		L.Push(lua.LNil)
+		return 1
+	}
+
+	tbl := L.NewTable()
+	tbl.RawSetString("table_name", lua.LString(resultSet.TableInfo.Name))
+
+	columns := L.NewTable()
+	for _, c := range resultSet.Columns() {
+		columns.Append(lua.LString(c))
+	}
+	tbl.RawSetString("columns", columns)
+
+	items := L.NewTable()
+	for _, item := range resultSet.Items() {
+		items.Append(itemToLua(L, item))
+	}
+	tbl.RawSetString("items", items)
+
+	L.Push(tbl)
+	return 1
+}
+
+func (s *luaScript) selectedItem(L *lua.LState) int {
+	resultSet := s.controller.state.ResultSet()
+	if resultSet == nil || s.selectedIdx < 0 || s.selectedIdx >= len(resultSet.Items()) {
+		L.Push(lua.LNil)
+		return 1
+	}
+
+	L.Push(itemToLua(L, resultSet.Items()[s.selectedIdx]))
+	return 1
+}
+
+func (s *luaScript) selectedIndex(L *lua.LState) int {
+	if s.selectedIdx < 0 {
+		L.Push(lua.LNil)
+	} else {
+		L.Push(lua.LNumber(s.selectedIdx + 1))
+	}
+	return 1
+}
+
+// runCmd runs the command and delivers the resulting message.  A Lua error is raised if the command fails.
+func (s *luaScript) runCmd(L *lua.LState, cmd tea.Cmd) int {
+	if cmd == nil {
+		return 0
+	}
+
+	msg := cmd()
+	if err, isErr := msg.(events.ErrorMsg); isErr {
+		L.RaiseError("%v", err)
+	} else if msg != nil {
+		s.controller.publisher.Send(msg)
+	}
+	return 0
+}
+
+func (s *luaScript) prompt(L *lua.LState) int {
+	result := make(chan lua.LValue, 1)
+	s.controller.publisher.Send(events.PromptForInputMsg{
+		Prompt: L.CheckString(1),
+		OnDone: func(value string) tea.Cmd {
+			result <- lua.LString(value)
+			return nil
+		},
+		OnCancel: func() tea.Cmd {
+			result <- lua.LNil
+			return nil
+		},
+	})
+
+	L.Push(<-result)
+	return 1
+}
+
+func b64decode(L *lua.LState) int {
+	bts, err := base64.StdEncoding.DecodeString(L.CheckString(1))
+	if err != nil {
+		L.RaiseError("invalid base64: %v", err)
+	}
+	L.Push(lua.LString(bts))
+	return 1
+}
+
+func b64encode(L *lua.LState) int {
+	L.Push(lua.LString(base64.StdEncoding.EncodeToString([]byte(L.CheckString(1)))))
+	return 1
+}
@@ -0,0 +1,97 @@
+package controllers_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/dynamo-browse/controllers"
+	"github.com/lmika/audax/internal/dynamo-browse/providers/dynamo"
+	"github.com/lmika/audax/internal/dynamo-browse/services/tables"
+	"github.com/lmika/audax/test/testdynamo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScriptController_LoadScript(t *testing.T) {
+	client := testdynamo.SetupTestTable(t, testData)
+
+	provider := dynamo.NewProvider(client)
+	service := tables.NewService(provider)
+
+	t.Run("should register commands which can read the selected item", func(t *testing.T) {
+		state := controllers.NewState()
+		readController := controllers.NewTableReadController(state, service, "alpha-table")
+		writeController := controllers.NewTableWriteController(state, service, readController)
+		scriptController := controllers.NewScriptController(state, readController, writeController)
+
+		publisher := &testPublisher{}
+		scriptController.SetPublisher(publisher)
+		scriptController.SetSelectedItemIndexFunc(func() int { return 0 })
+
+		invokeCommand(t, readController.Init())
+
+		err := scriptController.LoadScript(writeTestScript(t, `
+			audax.command("show-alpha", function()
+				local item = session.selected_item()
+				ui.status(item.pk .. ": " .. item.alpha)
+			end)
+		`))
+		assert.NoError(t, err)
+
+		cmd := scriptController.CommandContext().Commands["show-alpha"]
+		assert.NotNil(t, cmd)
+
+		invokeCommand(t, cmd(nil))
+		assert.Equal(t, []tea.Msg{events.StatusMsg("abc: This is some value")}, publisher.msgs)
+	})
+
+	t.Run("should set attributes of items", func(t *testing.T) {
+		state := controllers.NewState()
+		readController := controllers.NewTableReadController(state, service, "alpha-table")
+		writeController := controllers.NewTableWriteController(state, service, readController)
+		scriptController := controllers.NewScriptController(state, readController, writeController)
+
+		scriptController.SetPublisher(&testPublisher{})
+		scriptController.SetSelectedItemIndexFunc(func() int { return 1 })
+
+		invokeCommand(t, readController.Init())
+
+		err := scriptController.LoadScript(writeTestScript(t, `
+			audax.command("set-greeting", function(greeting)
+				session.set_attr(session.selected_index(), "greeting", greeting)
+			end)
+		`))
+		assert.NoError(t, err)
+
+		invokeCommand(t, scriptController.CommandContext().Commands["set-greeting"]([]string{"hello"}))
+
+		assert.Equal(t, &types.AttributeValueMemberS{Value: "hello"}, state.ResultSet().Items()[1]["greeting"])
+		assert.True(t, state.ResultSet().IsDirty(1))
+	})
+
+	t.Run("should return error if script fails to load", func(t *testing.T) {
+		state := controllers.NewState()
+		readController := controllers.NewTableReadController(state, service, "alpha-table")
+		scriptController := controllers.NewScriptController(state, readController, nil)
+
+		err := scriptController.LoadScript(writeTestScript(t, `session.selected_item()`))
+		assert.Error(t, err)
+	})
+}
+
+func writeTestScript(t *testing.T, script string) string {
+	filename := filepath.Join(t.TempDir(), "test.lua")
+	assert.NoError(t, os.WriteFile(filename, []byte(script), 0644))
+	return filename
+}
+
+type testPublisher struct {
+	msgs []tea.Msg
+}
+
+func (t *testPublisher) Send(msg tea.Msg) {
+	t.msgs = append(t.msgs, msg)
+}
@@ -0,0 +1,275 @@
+package controllers
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/pkg/errors"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// luaBinaryTypeName is the name of the Lua type of binary values.  Binary values are userdata, so that they are
+// converted back to binary attributes.  The bytes of a binary value can be read as a string using tostring().
+const luaBinaryTypeName = "binary"
+
+func registerBinaryType(L *lua.LState) {
+	mt := L.NewTypeMetatable(luaBinaryTypeName)
+	L.SetField(mt, "__tostring", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LString(checkBinary(L, 1)))
+		return 1
+	}))
+	L.SetField(mt, "__len", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LNumber(len(checkBinary(L, 1))))
+		return 1
+	}))
+	L.SetField(mt, "__eq", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LBool(string(checkBinary(L, 1)) == string(checkBinary(L, 2))))
+		return 1
+	}))
+}
+
+// luaDecimalTypeName is the name of the Lua type of numbers which cannot be held as a Lua number without losing
+// precision.  The number is kept as a string, which can be read using tostring(), so that it is converted back to the
+// same number attribute.
+const luaDecimalTypeName = "decimal"
+
+// decimal is the value of decimal userdata
+type decimal string
+
+func registerDecimalType(L *lua.LState) {
+	mt := L.NewTypeMetatable(luaDecimalTypeName)
+	L.SetField(mt, "__tostring", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LString(checkDecimal(L, 1)))
+		return 1
+	}))
+	L.SetField(mt, "__eq", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LBool(checkDecimal(L, 1) == checkDecimal(L, 2)))
+		return 1
+	}))
+}
+
+func checkDecimal(L *lua.LState, n int) decimal {
+	if d, isDecimal := L.CheckUserData(n).Value.(decimal); isDecimal {
+		return d
+	}
+	L.ArgError(n, "decimal expected")
+	return ""
+}
+
+// numberToLua converts a number attribute to a Lua number, or to a decimal if it cannot be held as a Lua number
+// without losing precision.
+func numberToLua(L *lua.LState, value string) lua.LValue {
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		if exact, isRat := new(big.Rat).SetString(value); isRat && exact.Cmp(new(big.Rat).SetFloat64(n)) == 0 {
+			return lua.LNumber(n)
+		}
+	}
+
+	ud := L.NewUserData()
+	ud.Value = decimal(value)
+	L.SetMetatable(ud, L.GetTypeMetatable(luaDecimalTypeName))
+	return ud
+}
+
+// luaSetTypes are the names of the Lua types of sets, keyed by the attribute type.  Sets are tables, holding the
+// elements as a list, with a metatable recording the attribute type so that they are converted back to sets.
+var luaSetTypes = map[string]string{
+	"SS": "stringset",
+	"NS": "numberset",
+	"BS": "binaryset",
+}
+
+// luaSetAttributeTypeField is the field of the metatable of sets holding the attribute type
+const luaSetAttributeTypeField = "__attributetype"
+
+func registerSetTypes(L *lua.LState) {
+	for attrType, typeName := range luaSetTypes {
+		mt := L.NewTypeMetatable(typeName)
+		L.SetField(mt, luaSetAttributeTypeField, lua.LString(attrType))
+	}
+}
+
+func setToLua(L *lua.LState, attrType string, elems []lua.LValue) *lua.LTable {
+	tbl := L.NewTable()
+	for _, e := range elems {
+		tbl.Append(e)
+	}
+	L.SetMetatable(tbl, L.GetTypeMetatable(luaSetTypes[attrType]))
+	return tbl
+}
+
+func binaryToLua(L *lua.LState, bts []byte) *lua.LUserData {
+	ud := L.NewUserData()
+	ud.Value = bts
+	L.SetMetatable(ud, L.GetTypeMetatable(luaBinaryTypeName))
+	return ud
+}
+
+func checkBinary(L *lua.LState, n int) []byte {
+	if bts, isBinary := L.CheckUserData(n).Value.([]byte); isBinary {
+		return bts
+	}
+	L.ArgError(n, "binary expected")
+	return nil
+}
+
+func itemToLua(L *lua.LState, item models.Item) *lua.LTable {
+	tbl := L.NewTable()
+	for k, v := range item {
+		tbl.RawSetString(k, attributeValueToLua(L, v))
+	}
+	return tbl
+}
+
+func attributeValueToLua(L *lua.LState, value types.AttributeValue) lua.LValue {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return lua.LString(v.Value)
+	case *types.AttributeValueMemberN:
+		return numberToLua(L, v.Value)
+	case *types.AttributeValueMemberBOOL:
+		return lua.LBool(v.Value)
+	case *types.AttributeValueMemberNULL:
+		return lua.LNil
+	case *types.AttributeValueMemberB:
+		return binaryToLua(L, v.Value)
+	case *types.AttributeValueMemberL:
+		tbl := L.NewTable()
+		for _, e := range v.Value {
+			tbl.Append(attributeValueToLua(L, e))
+		}
+		return tbl
+	case *types.AttributeValueMemberM:
+		tbl := L.NewTable()
+		for k, e := range v.Value {
+			tbl.RawSetString(k, attributeValueToLua(L, e))
+		}
+		return tbl
+	case *types.AttributeValueMemberSS:
+		elems := make([]lua.LValue, len(v.Value))
+		for i, e := range v.Value {
+			elems[i] = lua.LString(e)
+		}
+		return setToLua(L, "SS", elems)
+	case *types.AttributeValueMemberNS:
+		elems := make([]lua.LValue, len(v.Value))
+		for i, e := range v.Value {
+			elems[i] = numberToLua(L, e)
+		}
+		return setToLua(L, "NS", elems)
+	case *types.AttributeValueMemberBS:
+		elems := make([]lua.LValue, len(v.Value))
+		for i, e := range v.Value {
+			elems[i] = binaryToLua(L, e)
+		}
+		return setToLua(L, "BS", elems)
+	}
+	return lua.LNil
+}
+
+// luaToAttributeValue converts a Lua value to an attribute value.  Sets are converted back to sets.  Other tables with
+// only sequential integer keys are converted to lists; all other tables are converted to maps.  Binary values and
+// decimals are converted to binary and number attributes.
+func luaToAttributeValue(value lua.LValue) (types.AttributeValue, error) {
+	switch v := value.(type) {
+	case *lua.LNilType:
+		return &types.AttributeValueMemberNULL{Value: true}, nil
+	case lua.LString:
+		return &types.AttributeValueMemberS{Value: string(v)}, nil
+	case lua.LNumber:
+		return &types.AttributeValueMemberN{Value: v.String()}, nil
+	case lua.LBool:
+		return &types.AttributeValueMemberBOOL{Value: bool(v)}, nil
+	case *lua.LUserData:
+		switch uv := v.Value.(type) {
+		case []byte:
+			return &types.AttributeValueMemberB{Value: uv}, nil
+		case decimal:
+			return &types.AttributeValueMemberN{Value: string(uv)}, nil
+		}
+	case *lua.LTable:
+		if mt, hasMetatable := v.Metatable.(*lua.LTable); hasMetatable {
+			if attrType, isSet := mt.RawGetString(luaSetAttributeTypeField).(lua.LString); isSet {
+				return luaToSet(string(attrType), v)
+			}
+		}
+
+		if n := v.Len(); n > 0 {
+			list := make([]types.AttributeValue, 0, n)
+			for i := 1; i <= n; i++ {
+				e, err := luaToAttributeValue(v.RawGetInt(i))
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, e)
+			}
+			return &types.AttributeValueMemberL{Value: list}, nil
+		}
+
+		m := make(map[string]types.AttributeValue)
+		var err error
+		v.ForEach(func(k, e lua.LValue) {
+			if err != nil {
+				return
+			}
+			m[k.String()], err = luaToAttributeValue(e)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &types.AttributeValueMemberM{Value: m}, nil
+	}
+	return nil, errors.Errorf("unsupported value type: %v", value.Type())
+}
+
+// luaToSet converts the elements of a set to a set attribute.  The elements must all be of the set's type.
+func luaToSet(attrType string, tbl *lua.LTable) (types.AttributeValue, error) {
+	n := tbl.Len()
+	if n == 0 {
+		return nil, errors.Errorf("%v cannot be empty", luaSetTypes[attrType])
+	}
+
+	elems := make([]types.AttributeValue, 0, n)
+	for i := 1; i <= n; i++ {
+		e, err := luaToAttributeValue(tbl.RawGetInt(i))
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, e)
+	}
+
+	switch attrType {
+	case "SS":
+		strs := make([]string, len(elems))
+		for i, e := range elems {
+			s, isString := e.(*types.AttributeValueMemberS)
+			if !isString {
+				return nil, errors.Errorf("stringset element %d is not a string", i+1)
+			}
+			strs[i] = s.Value
+		}
+		return &types.AttributeValueMemberSS{Value: strs}, nil
+	case "NS":
+		nums := make([]string, len(elems))
+		for i, e := range elems {
+			n, isNumber := e.(*types.AttributeValueMemberN)
+			if !isNumber {
+				return nil, errors.Errorf("numberset element %d is not a number", i+1)
+			}
+			nums[i] = n.Value
+		}
+		return &types.AttributeValueMemberNS{Value: nums}, nil
+	}
+
+	bs := make([][]byte, len(elems))
+	for i, e := range elems {
+		b, isBinary := e.(*types.AttributeValueMemberB)
+		if !isBinary {
+			return nil, errors.Errorf("binaryset element %d is not a binary value", i+1)
+		}
+		bs[i] = b.Value
+	}
+	return &types.AttributeValueMemberBS{Value: bs}, nil
+}
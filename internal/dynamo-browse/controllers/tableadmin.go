@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/lmika/audax/internal/dynamo-browse/services/tables"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultReadUnits  = 5
+	defaultWriteUnits = 5
+)
+
+type TableAdminController struct {
+	state               *State
+	tableService        *tables.Service
+	tableReadController *TableReadController
+}
+
+func NewTableAdminController(state *State, tableService *tables.Service, tableReadController *TableReadController) *TableAdminController {
+	return &TableAdminController{
+		state:               state,
+		tableService:        tableService,
+		tableReadController: tableReadController,
+	}
+}
+
+// CreateTable creates a new table with the passed in spec.  Once created, the new table will be scanned.
+func (c *TableAdminController) CreateTable(spec models.TableSpec) tea.Cmd {
+	if spec.Name == "" {
+		return events.SetError(errors.New("table name must be set"))
+	} else if spec.Keys.PartitionKey.Name == "" {
+		return events.SetError(errors.New("partition key must be set"))
+	}
+
+	if !spec.OnDemand {
+		if spec.ReadUnits == 0 {
+			spec.ReadUnits = defaultReadUnits
+		}
+		if spec.WriteUnits == 0 {
+			spec.WriteUnits = defaultWriteUnits
+		}
+	}
+
+	return events.Confirm("create table "+spec.Name+" ("+spec.Keys.String()+")? ", func() tea.Cmd {
+		return func() tea.Msg {
+			if err := c.tableService.CreateTable(context.Background(), spec); err != nil {
+				return events.Error(err)
+			}
+			c.tableReadController.refreshTableNames()
+			return c.tableReadController.ScanTable(spec.Name)()
+		}
+	})
+}
+
+// DeleteTable deletes the table with the given name.  If the name is empty, the current table will be deleted.
+// The user will be asked to type the name of the table to confirm the deletion.
+func (c *TableAdminController) DeleteTable(tableName string) tea.Cmd {
+	if tableName == "" {
+		resultSet := c.state.ResultSet()
+		if resultSet == nil {
+			return events.SetError(errors.New("no table selected"))
+		}
+		tableName = resultSet.TableInfo.Name
+	}
+
+	return events.PromptForInput("type '"+tableName+"' to delete table: ", func(value string) tea.Cmd {
+		if value != tableName {
+			return events.SetStatus("operation aborted")
+		}
+
+		return func() tea.Msg {
+			if err := c.tableService.DeleteTable(context.Background(), tableName); err != nil {
+				return events.Error(err)
+			}
+			return c.tableReadController.ListTables()()
+		}
+	})
+}
+
+// CloneTable creates a new table with the same schema as the source table, optionally copying the items across.
+// If the source table is empty, the current table will be cloned.  Once cloned, the new table will be scanned.
+func (c *TableAdminController) CloneTable(sourceTable, newTableName string, copyItems bool) tea.Cmd {
+	if sourceTable == "" {
+		resultSet := c.state.ResultSet()
+		if resultSet == nil {
+			return events.SetError(errors.New("no table selected"))
+		}
+		sourceTable = resultSet.TableInfo.Name
+	}
+	if newTableName == "" {
+		return events.SetError(errors.New("new table name must be set"))
+	}
+
+	return func() tea.Msg {
+		copied, err := c.tableService.CloneTable(context.Background(), sourceTable, newTableName, copyItems)
+		if err != nil {
+			return events.Error(err)
+		}
+		c.tableReadController.refreshTableNames()
+
+		msg := c.tableReadController.ScanTable(newTableName)()
+		if newResultSet, isNewResultSet := msg.(NewResultSet); isNewResultSet {
+			statusMessage := fmt.Sprintf("cloned %v to %v", sourceTable, newTableName)
+			if copyItems {
+				statusMessage = applyToN(statusMessage+", ", copied, "item", "items", " copied")
+			}
+			newResultSet.statusMessage = statusMessage
+			return newResultSet
+		}
+		return msg
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/lmika/audax/internal/dynamo-browse/models"
 	"github.com/lmika/audax/internal/dynamo-browse/models/queryexpr"
 	"github.com/pkg/errors"
+	"log"
 	"os"
 	"sync"
 )
@@ -17,8 +18,10 @@ type TableReadController struct {
 	tableName    string
 
 	// state
-	mutex *sync.Mutex
-	state *State
+	mutex             *sync.Mutex
+	state             *State
+	tableNames        []string
+	loadingTableNames bool
 	//resultSet *models.ResultSet
 	//filter    string
 }
@@ -37,6 +40,7 @@ func (c *TableReadController) Init() tea.Cmd {
 	if c.tableName == "" {
 		return c.ListTables()
 	} else {
+		c.refreshTableNames()
 		return c.ScanTable(c.tableName)
 	}
 }
@@ -47,6 +51,7 @@ func (c *TableReadController) ListTables() tea.Cmd {
 		if err != nil {
 			return events.Error(err)
 		}
+		c.setTableNames(tables)
 
 		return PromptForTableMsg{
 			Tables: tables,
@@ -57,6 +62,58 @@ func (c *TableReadController) ListTables() tea.Cmd {
 	}
 }
 
+// TableNames returns the names of the tables last listed.  This does not block, as it is used for completion: if
+// the tables have not been listed, they will be listed in the background and no names will be returned until then.
+func (c *TableReadController) TableNames() []string {
+	c.mutex.Lock()
+	tableNames := c.tableNames
+	c.mutex.Unlock()
+
+	if tableNames == nil {
+		c.refreshTableNames()
+	}
+	return tableNames
+}
+
+// refreshTableNames lists the tables in the background, unless they are already being listed.
+func (c *TableReadController) refreshTableNames() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.loadingTableNames {
+		return
+	}
+	c.loadingTableNames = true
+
+	go func() {
+		tables, err := c.tableService.ListTables(context.Background())
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		c.loadingTableNames = false
+		if err != nil {
+			log.Printf("cannot list tables: %v", err)
+			return
+		}
+		c.tableNames = tables
+	}()
+}
+
+// AttributeNames returns the names of the attributes of the current result set.
+func (c *TableReadController) AttributeNames() []string {
+	if resultSet := c.state.ResultSet(); resultSet != nil {
+		return resultSet.Columns()
+	}
+	return nil
+}
+
+func (c *TableReadController) setTableNames(tableNames []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.tableNames = tableNames
+}
+
 func (c *TableReadController) ScanTable(name string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
@@ -76,37 +133,77 @@ func (c *TableReadController) ScanTable(name string) tea.Cmd {
 }
 
 func (c *TableReadController) PromptForQuery() tea.Cmd {
-	return func() tea.Msg {
-		return events.PromptForInputMsg{
-			Prompt: "query: ",
-			OnDone: func(value string) tea.Cmd {
-				if value == "" {
-					return func() tea.Msg {
-						resultSet := c.state.ResultSet()
-						return c.doScan(context.Background(), resultSet, nil)
-					}
-				}
+	return events.PromptForInput("query: ", c.RunQuery)
+}
 
-				expr, err := queryexpr.Parse(value)
-				if err != nil {
-					return events.SetError(err)
-				}
+// RunQuery parses and runs the passed in query against the current table.  If the query is empty, a scan of
+// the table will be performed.
+func (c *TableReadController) RunQuery(value string) tea.Cmd {
+	if value == "" {
+		return func() tea.Msg {
+			resultSet := c.state.ResultSet()
+			return c.doScan(context.Background(), resultSet, nil)
+		}
+	}
 
-				return c.doIfNoneDirty(func() tea.Msg {
-					resultSet := c.state.ResultSet()
-					newResultSet, err := c.tableService.ScanOrQuery(context.Background(), resultSet.TableInfo, expr)
-					if err != nil {
-						return events.Error(err)
-					}
+	expr, err := queryexpr.Parse(value)
+	if err != nil {
+		return events.SetError(err)
+	}
 
-					return c.setResultSetAndFilter(newResultSet, "")
-				})
-			},
+	return c.doIfNoneDirty(func() tea.Msg {
+		resultSet := c.state.ResultSet()
+		newResultSet, err := c.tableService.ScanOrQuery(context.Background(), resultSet.TableInfo, expr)
+		if err != nil {
+			return events.Error(err)
 		}
+
+		return c.setResultSetAndFilter(newResultSet, "")
+	})
+}
+
+// PromptForStatement prompts the user for a PartiQL statement and executes it.
+func (c *TableReadController) PromptForStatement() tea.Cmd {
+	return events.PromptForInput("sql: ", c.RunStatement)
+}
+
+// RunStatement executes a PartiQL statement.  The results of SELECT statements are loaded as the current
+// result set.  Other statements modify items, so the user is asked to confirm them first.
+func (c *TableReadController) RunStatement(value string) tea.Cmd {
+	if value == "" {
+		return nil
 	}
+
+	stmt := &models.PartiQLStatement{Statement: value}
+	if !stmt.IsSelect() {
+		return events.Confirm("execute "+stmt.Statement+"? ", func() tea.Cmd {
+			return func() tea.Msg {
+				_, affected, err := c.tableService.ExecuteStatement(context.Background(), stmt)
+				if err != nil {
+					return events.Error(err)
+				} else if affected < 0 {
+					return events.StatusMsg("statement executed")
+				}
+				return events.StatusMsg(applyToN("", affected, "item", "items", " affected"))
+			}
+		})
+	}
+
+	return c.doIfNoneDirty(func() tea.Msg {
+		newResultSet, _, err := c.tableService.ExecuteStatement(context.Background(), stmt)
+		if err != nil {
+			return events.Error(err)
+		}
+
+		return c.setResultSetAndFilter(newResultSet, "")
+	})
 }
 
 func (c *TableReadController) doIfNoneDirty(cmd tea.Cmd) tea.Cmd {
+	if c.state.ResultSet() == nil {
+		return cmd
+	}
+
 	var anyDirty = false
 	for i := 0; i < len(c.state.ResultSet().Items()); i++ {
 		anyDirty = anyDirty || c.state.ResultSet().IsDirty(i)
@@ -201,17 +298,16 @@ func (c *TableReadController) Unmark() tea.Cmd {
 }
 
 func (c *TableReadController) Filter() tea.Cmd {
+	return events.PromptForInput("filter: ", c.SetFilter)
+}
+
+// SetFilter filters the current result set to items matching the passed in filter.  An empty filter will
+// clear the current filter.
+func (c *TableReadController) SetFilter(value string) tea.Cmd {
 	return func() tea.Msg {
-		return events.PromptForInputMsg{
-			Prompt: "filter: ",
-			OnDone: func(value string) tea.Cmd {
-				return func() tea.Msg {
-					resultSet := c.state.ResultSet()
-					newResultSet := c.tableService.Filter(resultSet, value)
+		resultSet := c.state.ResultSet()
+		newResultSet := c.tableService.Filter(resultSet, value)
 
-					return c.setResultSetAndFilter(newResultSet, value)
-				}
-			},
-		}
+		return c.setResultSetAndFilter(newResultSet, value)
 	}
 }
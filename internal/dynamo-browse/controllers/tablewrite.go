@@ -229,6 +229,31 @@ func (twc *TableWriteController) setNullValue(idx int, attr attrPath) tea.Cmd {
 	}
 }
 
+// SetItemAttribute sets the attribute of the item at idx to the passed in value.  Unlike SetAttributeValue, the
+// value is not prompted for and marked items are not modified.
+func (twc *TableWriteController) SetItemAttribute(idx int, key string, value types.AttributeValue) tea.Cmd {
+	return func() tea.Msg {
+		apPath := newAttrPath(key)
+
+		if err := twc.state.withResultSetReturningError(func(set *models.ResultSet) error {
+			if idx < 0 || idx >= len(set.Items()) {
+				return errors.Errorf("no item at index %v", idx)
+			}
+			if err := apPath.setAt(set.Items()[idx], value); err != nil {
+				return err
+			}
+
+			set.SetDirty(idx, true)
+			set.RefreshColumns()
+			return nil
+		}); err != nil {
+			return events.Error(err)
+		}
+
+		return ResultSetUpdated{}
+	}
+}
+
 func (twc *TableWriteController) DeleteAttribute(idx int, key string) tea.Cmd {
 	return func() tea.Msg {
 		// Verify that the expression is valid
@@ -263,6 +288,8 @@ func (twc *TableWriteController) PutItem(idx int) tea.Cmd {
 		resultSet := twc.state.ResultSet()
 		if !resultSet.IsDirty(idx) {
 			return events.Error(errors.New("item is not dirty"))
+		} else if resultSet.ReadOnly {
+			return events.Error(tables.ErrReadOnlyResultSet)
 		}
 
 		return events.PromptForInputMsg{
@@ -290,6 +317,10 @@ func (twc *TableWriteController) PutItems() tea.Cmd {
 		)
 		var itemsToPut []models.ItemIndex
 
+		if resultSet := twc.state.ResultSet(); resultSet != nil && resultSet.ReadOnly {
+			return events.Error(tables.ErrReadOnlyResultSet)
+		}
+
 		twc.state.withResultSet(func(rs *models.ResultSet) {
 			if markedItems := rs.MarkedItems(); len(markedItems) > 0 {
 				for _, mi := range markedItems {
@@ -354,6 +385,8 @@ func (twc *TableWriteController) TouchItem(idx int) tea.Cmd {
 		resultSet := twc.state.ResultSet()
 		if resultSet.IsDirty(idx) {
 			return events.Error(errors.New("cannot touch dirty items"))
+		} else if resultSet.ReadOnly {
+			return events.Error(tables.ErrReadOnlyResultSet)
 		}
 
 		return events.PromptForInputMsg{
@@ -379,6 +412,8 @@ func (twc *TableWriteController) NoisyTouchItem(idx int) tea.Cmd {
 		resultSet := twc.state.ResultSet()
 		if resultSet.IsDirty(idx) {
 			return events.Error(errors.New("cannot noisy touch dirty items"))
+		} else if resultSet.ReadOnly {
+			return events.Error(tables.ErrReadOnlyResultSet)
 		}
 
 		return events.PromptForInputMsg{
@@ -414,6 +449,8 @@ func (twc *TableWriteController) DeleteMarked() tea.Cmd {
 
 		if len(markedItems) == 0 {
 			return events.StatusMsg("no marked items")
+		} else if resultSet.ReadOnly {
+			return events.Error(tables.ErrReadOnlyResultSet)
 		}
 
 		return events.PromptForInputMsg{
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"encoding/json"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/clipboard"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/pkg/errors"
+)
+
+// YankItem copies the item at idx to the clipboard as JSON.
+func (c *TableReadController) YankItem(idx int) tea.Cmd {
+	return func() tea.Msg {
+		item, err := c.itemAt(idx)
+		if err != nil {
+			return events.Error(err)
+		}
+
+		bts, err := json.MarshalIndent(models.ItemToJSONValue(item), "", "  ")
+		if err != nil {
+			return events.Error(err)
+		}
+		return yank("item", string(bts))
+	}
+}
+
+// YankAttribute copies the value of an attribute of the item at idx to the clipboard.  Strings are copied
+// as is while all other values are copied as JSON.  Nested attributes can be addressed by
+// joining the names with dots.
+func (c *TableReadController) YankAttribute(idx int, attr string) tea.Cmd {
+	return func() tea.Msg {
+		item, err := c.itemAt(idx)
+		if err != nil {
+			return events.Error(err)
+		}
+
+		av, err := newAttrPath(attr).follow(item)
+		if err != nil {
+			return events.Error(err)
+		}
+
+		jv := models.AttributeValueToJSONValue(av)
+		if s, isString := jv.(string); isString {
+			return yank(attr, s)
+		}
+
+		bts, err := json.MarshalIndent(jv, "", "  ")
+		if err != nil {
+			return events.Error(err)
+		}
+		return yank(attr, string(bts))
+	}
+}
+
+// YankMarked copies the marked items to the clipboard as JSON, one item per line.
+func (c *TableReadController) YankMarked() tea.Cmd {
+	return func() tea.Msg {
+		resultSet := c.state.ResultSet()
+		if resultSet == nil {
+			return events.Error(errors.New("no result set"))
+		}
+
+		markedItems := resultSet.MarkedItems()
+		if len(markedItems) == 0 {
+			return events.Error(errors.New("no marked items"))
+		}
+
+		var sb strings.Builder
+		enc := json.NewEncoder(&sb)
+		for _, mi := range markedItems {
+			if err := enc.Encode(models.ItemToJSONValue(mi.Item)); err != nil {
+				return events.Error(err)
+			}
+		}
+		return yank(applyToN("", len(markedItems), "item", "items", ""), sb.String())
+	}
+}
+
+func (c *TableReadController) itemAt(idx int) (models.Item, error) {
+	resultSet := c.state.ResultSet()
+	if resultSet == nil {
+		return nil, errors.New("no result set")
+	} else if idx < 0 || idx >= len(resultSet.Items()) {
+		return nil, errors.New("no item selected")
+	}
+	return resultSet.Items()[idx], nil
+}
+
+func yank(what string, text string) tea.Msg {
+	if err := clipboard.Write(text); err != nil {
+		return events.Error(err)
+	}
+	return events.StatusMsg("copied " + what + " to clipboard")
+}
@@ -0,0 +1,104 @@
+package headless
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/pkg/errors"
+)
+
+// Format is an output format of a result set
+type Format string
+
+const (
+	FormatJSONL Format = "jsonl"
+	FormatJSON  Format = "json"
+	FormatCSV   Format = "csv"
+
+	// FormatDynamoDBJSONL is one DynamoDB JSON object per line, which preserves the types of all attributes
+	FormatDynamoDBJSONL Format = "dynamodb-jsonl"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatJSONL, FormatJSON, FormatCSV, FormatDynamoDBJSONL:
+		return f, nil
+	}
+	return "", errors.Errorf("unrecognised format '%v': expected jsonl, json, csv or dynamodb-jsonl", s)
+}
+
+// ParseInputFormat parses the format of items being put.
+func ParseInputFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatJSONL, FormatDynamoDBJSONL:
+		return f, nil
+	}
+	return "", errors.Errorf("unrecognised input format '%v': expected jsonl or dynamodb-jsonl", s)
+}
+
+// losesTypes returns true if writing the result set in the format loses the types of some attributes, such as
+// binary values and sets written as JSON strings and arrays.
+func losesTypes(format Format, resultSet *models.ResultSet) bool {
+	if format != FormatJSONL && format != FormatJSON {
+		return false
+	}
+	for _, item := range resultSet.Items() {
+		if models.ItemLosesTypesAsJSONValue(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeResultSet(w io.Writer, format Format, resultSet *models.ResultSet) error {
+	switch format {
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		for _, item := range resultSet.Items() {
+			if err := enc.Encode(models.ItemToJSONValue(item)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatDynamoDBJSONL:
+		enc := json.NewEncoder(w)
+		for _, item := range resultSet.Items() {
+			if err := enc.Encode(models.ItemToDynamoDBJSON(item)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatJSON:
+		items := make([]map[string]any, len(resultSet.Items()))
+		for i, item := range resultSet.Items() {
+			items[i] = models.ItemToJSONValue(item)
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+
+		columns := resultSet.Columns()
+		if err := cw.Write(columns); err != nil {
+			return err
+		}
+
+		row := make([]string, len(columns))
+		for _, item := range resultSet.Items() {
+			for i, col := range columns {
+				row[i], _ = item.AttributeValueAsString(col)
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+
+		cw.Flush()
+		return cw.Error()
+	}
+	return errors.Errorf("unrecognised format: %v", format)
+}
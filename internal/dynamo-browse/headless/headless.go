@@ -0,0 +1,171 @@
+// Package headless runs operations against tables without the UI, for use in shell scripts and CI jobs.
+package headless
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/lmika/audax/internal/dynamo-browse/models/queryexpr"
+	"github.com/lmika/audax/internal/dynamo-browse/services/tables"
+	"github.com/pkg/errors"
+)
+
+const putBatchSize = 25
+
+type Runner struct {
+	tableService *tables.Service
+	out          io.Writer
+	warnOut      io.Writer
+}
+
+// NewRunner creates a new runner which writes results to out.  Warnings, such as results being truncated, are
+// written to warnOut.
+func NewRunner(tableService *tables.Service, out io.Writer, warnOut io.Writer) *Runner {
+	return &Runner{tableService: tableService, out: out, warnOut: warnOut}
+}
+
+// Query runs the query against the table and writes the results in the passed in format.  If the query is
+// empty, the table is scanned.
+func (r *Runner) Query(ctx context.Context, tableName string, query string, format Format) error {
+	resultSet, err := r.query(ctx, tableName, query)
+	if err != nil {
+		return err
+	}
+	r.warnIfLosesTypes(format, resultSet)
+	return writeResultSet(r.out, format, resultSet)
+}
+
+// Export runs the query against the table and writes the results to the named file.
+func (r *Runner) Export(ctx context.Context, tableName string, query string, format Format, filename string) (int, error) {
+	resultSet, err := r.query(ctx, tableName, query)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot export to '%v'", filename)
+	}
+	defer f.Close()
+
+	r.warnIfLosesTypes(format, resultSet)
+	if err := writeResultSet(f, format, resultSet); err != nil {
+		return 0, errors.Wrapf(err, "cannot export to '%v'", filename)
+	}
+	return len(resultSet.Items()), f.Close()
+}
+
+// Put reads items, one JSON object per line in the passed in format, and puts them in the table.  It returns the
+// number of items put.
+func (r *Runner) Put(ctx context.Context, tableName string, in io.Reader, format Format) (int, error) {
+	itemFromJSON := models.ItemFromJSONValue
+	if format == FormatDynamoDBJSONL {
+		itemFromJSON = models.ItemFromDynamoDBJSON
+	}
+
+	tableInfo, err := r.tableService.Describe(ctx, tableName)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot describe %v", tableName)
+	}
+
+	dec := json.NewDecoder(bufio.NewReader(in))
+	dec.UseNumber()
+
+	var (
+		count int
+		batch = make([]models.Item, 0, putBatchSize)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := r.tableService.PutItems(ctx, tableInfo, batch); err != nil {
+			return errors.Wrapf(err, "cannot put items")
+		}
+		count += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for line := 1; ; line++ {
+		var m map[string]any
+		if err := dec.Decode(&m); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return count, errors.Wrapf(err, "item %d", line)
+		}
+
+		item, err := itemFromJSON(m)
+		if err != nil {
+			return count, errors.Wrapf(err, "item %d", line)
+		}
+		if _, hasKey := item[tableInfo.Keys.PartitionKey]; !hasKey {
+			return count, errors.Errorf("item %d: missing partition key '%v'", line, tableInfo.Keys.PartitionKey)
+		}
+
+		batch = append(batch, item)
+		if len(batch) == putBatchSize {
+			if err := flush(); err != nil {
+				return count, err
+			}
+		}
+	}
+
+	return count, flush()
+}
+
+// Delete deletes the items matching the query.  It returns the number of items deleted, which will be less than
+// the number of matching items if an error was returned.
+func (r *Runner) Delete(ctx context.Context, tableName string, query string) (int, error) {
+	resultSet, err := r.query(ctx, tableName, query)
+	if err != nil {
+		return 0, err
+	}
+
+	items := resultSet.Items()
+	for i, item := range items {
+		if err := r.tableService.Delete(ctx, resultSet.TableInfo, []models.Item{item}); err != nil {
+			return i, errors.Wrapf(err, "deleted %d of %d items", i, len(items))
+		}
+	}
+	return len(items), nil
+}
+
+func (r *Runner) query(ctx context.Context, tableName string, query string) (*models.ResultSet, error) {
+	tableInfo, err := r.tableService.Describe(ctx, tableName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot describe %v", tableName)
+	}
+
+	var resultSet *models.ResultSet
+	if query == "" {
+		resultSet, err = r.tableService.Scan(ctx, tableInfo)
+	} else {
+		expr, parseErr := queryexpr.Parse(query)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		resultSet, err = r.tableService.ScanOrQuery(ctx, tableInfo, expr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if limit := r.tableService.ScanLimit(); len(resultSet.Items()) >= limit {
+		fmt.Fprintf(r.warnOut, "warning: results truncated to %d items: use -limit to read more\n", limit)
+	}
+	return resultSet, nil
+}
+
+// warnIfLosesTypes warns if writing the result set in the format will lose the types of some attributes, which
+// would then be changed if the items were put back into a table.
+func (r *Runner) warnIfLosesTypes(format Format, resultSet *models.ResultSet) {
+	if losesTypes(format, resultSet) {
+		fmt.Fprintf(r.warnOut, "warning: binary and set attributes lose their types when written as %v: use -o %v to preserve them\n", format, FormatDynamoDBJSONL)
+	}
+}
@@ -0,0 +1,207 @@
+package itemrender
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Decoder transforms the bytes of a binary or string value.
+type Decoder struct {
+	Name        string
+	Description string
+
+	// display is true for decoders which determine how the result is displayed.  These can only be the last
+	// decoder of the chain.
+	display bool
+	decode  func(b []byte) ([]byte, error)
+}
+
+var decoders = map[string]Decoder{
+	"base64": {Name: "base64", Description: "decode base64", decode: decodeBase64},
+	"gzip":   {Name: "gzip", Description: "decompress gzip", decode: decodeGzip},
+	"hex":    {Name: "hex", Description: "display as a hex dump", display: true},
+	"utf8":   {Name: "utf8", Description: "display as UTF-8 text, or a tree if it is JSON", display: true},
+}
+
+// DecoderNames returns the names of the available decoders.
+func DecoderNames() []string {
+	names := make([]string, 0, len(decoders))
+	for name := range decoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseDecoders parses a chain of decoder names.  Only the last decoder of the chain can be a display decoder.
+func ParseDecoders(names []string) ([]Decoder, error) {
+	chain := make([]Decoder, len(names))
+	for i, name := range names {
+		d, ok := decoders[strings.ToLower(name)]
+		if !ok {
+			return nil, errors.Errorf("unrecognised decoder '%v': expected one of %v", name, strings.Join(DecoderNames(), ", "))
+		} else if d.display && i < len(names)-1 {
+			return nil, errors.Errorf("decoder '%v' must be last", name)
+		}
+		chain[i] = d
+	}
+	return chain, nil
+}
+
+// Decode returns a renderer displaying the value of r decoded by the decoder chain.  Only binary and string
+// values can be decoded; other renderers are returned as is.  If the chain does not end with a display decoder,
+// the result is displayed as UTF-8 if it is valid, otherwise as a hex dump.
+func Decode(r Renderer, chain []Decoder) Renderer {
+	var (
+		bts []byte
+		err error
+	)
+	switch v := r.(type) {
+	case *BinaryRenderer:
+		bts = v.Value
+	case *StringRenderer:
+		bts = []byte(v.Value)
+	default:
+		return r
+	}
+
+	var transforms []string
+	display := ""
+	for _, d := range chain {
+		if d.display {
+			display = d.Name
+			continue
+		}
+		transforms = append(transforms, d.Name)
+		if bts, err = d.decode(bts); err != nil {
+			return &DecodedRenderer{typeName: r.TypeName(), transforms: transforms, err: errors.Wrap(err, "cannot decode")}
+		}
+	}
+
+	if display == "" {
+		if utf8.Valid(bts) {
+			display = "utf8"
+		} else {
+			display = "hex"
+		}
+	}
+
+	return &DecodedRenderer{typeName: r.TypeName(), transforms: transforms, display: display, value: bts}
+}
+
+// DecodedRenderer renders a decoded binary or string value.
+type DecodedRenderer struct {
+	typeName   string
+	transforms []string
+	display    string
+	value      []byte
+	err        error
+}
+
+func (dr *DecodedRenderer) TypeName() string {
+	return dr.typeName
+}
+
+func (dr *DecodedRenderer) StringValue() string {
+	if dr.err != nil || dr.display != "utf8" {
+		return ""
+	}
+	return string(dr.value)
+}
+
+func (dr *DecodedRenderer) MetaInfo() string {
+	if dr.err != nil {
+		return fmt.Sprintf("(%v)", dr.err)
+	}
+
+	info := fmt.Sprintf("%d bytes, %v", len(dr.value), dr.display)
+	if len(dr.transforms) > 0 {
+		info = strings.Join(dr.transforms, " → ") + " → " + info
+	}
+	return "(" + info + ")"
+}
+
+func (dr *DecodedRenderer) SubItems() []SubItem {
+	if dr.err != nil {
+		return nil
+	}
+
+	switch dr.display {
+	case "hex":
+		return dumpLines(hex.Dump(dr.value), 10)
+	case "utf8":
+		if v, isJSON := parseJSON(string(dr.value)); isJSON {
+			return (&JSONRenderer{value: v}).SubItems()
+		} else if bytes.ContainsRune(dr.value, '\n') {
+			return textLines(string(dr.value))
+		}
+	}
+	return nil
+}
+
+func decodeBase64(b []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(b)
+	if bts, err := base64.StdEncoding.DecodeString(string(trimmed)); err == nil {
+		return bts, nil
+	}
+	return base64.RawStdEncoding.DecodeString(string(bytes.TrimRight(trimmed, "=")))
+}
+
+func decodeGzip(b []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// lineRenderer renders a single line of decoded text
+type lineRenderer string
+
+func (lr lineRenderer) TypeName() string {
+	return ""
+}
+
+func (lr lineRenderer) StringValue() string {
+	return string(lr)
+}
+
+func (lr lineRenderer) MetaInfo() string {
+	return ""
+}
+
+func (lr lineRenderer) SubItems() []SubItem {
+	return nil
+}
+
+func textLines(s string) []SubItem {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	subitems := make([]SubItem, len(lines))
+	for i, line := range lines {
+		subitems[i] = SubItem{Key: fmt.Sprint(i + 1), Value: lineRenderer(line)}
+	}
+	return subitems
+}
+
+// dumpLines splits a hex dump into sub-items keyed by the offset, which is the first keyLen characters of the line
+func dumpLines(dump string, keyLen int) []SubItem {
+	lines := strings.Split(strings.TrimSuffix(dump, "\n"), "\n")
+	subitems := make([]SubItem, 0, len(lines))
+	for _, line := range lines {
+		if len(line) < keyLen {
+			continue
+		}
+		subitems = append(subitems, SubItem{Key: strings.TrimSpace(line[:keyLen]), Value: lineRenderer(line[keyLen:])})
+	}
+	return subitems
+}
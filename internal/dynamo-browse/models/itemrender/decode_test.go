@@ -0,0 +1,117 @@
+package itemrender_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/lmika/audax/internal/dynamo-browse/models/itemrender"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringRenderer_JSON(t *testing.T) {
+	t.Run("should render JSON objects as sub-items", func(t *testing.T) {
+		r := itemrender.ToRenderer(&types.AttributeValueMemberS{Value: `{"b": [1, true], "a": "x"}`})
+
+		assert.Equal(t, "(JSON object)", r.MetaInfo())
+
+		subitems := r.SubItems()
+		assert.Len(t, subitems, 2)
+		assert.Equal(t, "a", subitems[0].Key)
+		assert.Equal(t, "x", subitems[0].Value.StringValue())
+		assert.Equal(t, "b", subitems[1].Key)
+		assert.Equal(t, "array", subitems[1].Value.TypeName())
+		assert.Equal(t, "1", subitems[1].Value.SubItems()[0].Value.StringValue())
+		assert.Equal(t, "true", subitems[1].Value.SubItems()[1].Value.StringValue())
+	})
+
+	t.Run("should not render other strings as JSON", func(t *testing.T) {
+		for _, s := range []string{"hello", "123", `"quoted"`, "{not json}", `{"a": 1} trailing`} {
+			r := itemrender.ToRenderer(&types.AttributeValueMemberS{Value: s})
+			assert.Equal(t, "", r.MetaInfo(), s)
+			assert.Empty(t, r.SubItems(), s)
+		}
+	})
+}
+
+func TestDecode(t *testing.T) {
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write([]byte(`{"msg": "hello"}`))
+	gw.Close()
+
+	scenarios := []struct {
+		name      string
+		value     types.AttributeValue
+		decoders  []string
+		wantValue string
+		wantMeta  string
+		wantSubs  int
+	}{
+		{
+			name:      "gzip binary as JSON",
+			value:     &types.AttributeValueMemberB{Value: gzipped.Bytes()},
+			decoders:  []string{"gzip"},
+			wantValue: `{"msg": "hello"}`,
+			wantMeta:  "(gzip → 16 bytes, utf8)",
+			wantSubs:  1,
+		},
+		{
+			name:      "base64 string",
+			value:     &types.AttributeValueMemberS{Value: base64.StdEncoding.EncodeToString([]byte("hello"))},
+			decoders:  []string{"base64"},
+			wantValue: "hello",
+			wantMeta:  "(base64 → 5 bytes, utf8)",
+		},
+		{
+			name:     "base64 then gzip",
+			value:    &types.AttributeValueMemberS{Value: base64.StdEncoding.EncodeToString(gzipped.Bytes())},
+			decoders: []string{"base64", "gzip"},
+			wantMeta: "(base64 → gzip → 16 bytes, utf8)",
+			wantSubs: 1,
+		},
+		{
+			name:     "hex dump",
+			value:    &types.AttributeValueMemberB{Value: bytes.Repeat([]byte{0xff}, 20)},
+			decoders: []string{"hex"},
+			wantMeta: "(20 bytes, hex)",
+			wantSubs: 2,
+		},
+		{
+			name:     "invalid UTF-8 defaults to hex",
+			value:    &types.AttributeValueMemberB{Value: []byte{0xff, 0xfe}},
+			wantMeta: "(2 bytes, hex)",
+			wantSubs: 1,
+		},
+		{
+			name:     "decode error",
+			value:    &types.AttributeValueMemberB{Value: []byte("not gzip")},
+			decoders: []string{"gzip"},
+			wantMeta: "(cannot decode: unexpected EOF)",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			chain, err := itemrender.ParseDecoders(scenario.decoders)
+			assert.NoError(t, err)
+
+			r := itemrender.Decode(itemrender.ToRenderer(scenario.value), chain)
+			if scenario.wantValue != "" {
+				assert.Equal(t, scenario.wantValue, r.StringValue())
+			}
+			assert.Equal(t, scenario.wantMeta, r.MetaInfo())
+			assert.Len(t, r.SubItems(), scenario.wantSubs)
+		})
+	}
+}
+
+func TestParseDecoders(t *testing.T) {
+	_, err := itemrender.ParseDecoders([]string{"rot13"})
+	assert.Error(t, err)
+
+	_, err = itemrender.ParseDecoders([]string{"hex", "gzip"})
+	assert.Error(t, err)
+}
@@ -14,8 +14,7 @@ func ToRenderer(v types.AttributeValue) Renderer {
 	case nil:
 		return nil
 	case *types.AttributeValueMemberS:
-		x := StringRenderer(*colVal)
-		return &x
+		return newStringRenderer(colVal.Value)
 	case *types.AttributeValueMemberN:
 		x := NumberRenderer(*colVal)
 		return &x
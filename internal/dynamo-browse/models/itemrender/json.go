@@ -0,0 +1,88 @@
+package itemrender
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// JSONRenderer renders a decoded JSON value.  Objects and arrays are rendered as sub-items.
+type JSONRenderer struct {
+	value any
+}
+
+// parseJSON returns the decoded JSON value of s if it is a JSON object or array.
+func parseJSON(s string) (any, bool) {
+	trimmed := bytes.TrimSpace([]byte(s))
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return nil, false
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return nil, false
+	}
+	return v, true
+}
+
+func (jr *JSONRenderer) TypeName() string {
+	switch jr.value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	}
+	return "null"
+}
+
+func (jr *JSONRenderer) StringValue() string {
+	switch v := jr.value.(type) {
+	case map[string]any, []any:
+		return ""
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (jr *JSONRenderer) MetaInfo() string {
+	switch v := jr.value.(type) {
+	case map[string]any:
+		return cardinality(len(v), "item", "items")
+	case []any:
+		return cardinality(len(v), "item", "items")
+	}
+	return ""
+}
+
+func (jr *JSONRenderer) SubItems() []SubItem {
+	switch v := jr.value.(type) {
+	case map[string]any:
+		subitems := make([]SubItem, 0, len(v))
+		for k, e := range v {
+			subitems = append(subitems, SubItem{Key: k, Value: &JSONRenderer{value: e}})
+		}
+		sort.Slice(subitems, func(i, j int) bool {
+			return subitems[i].Key < subitems[j].Key
+		})
+		return subitems
+	case []any:
+		subitems := make([]SubItem, len(v))
+		for i, e := range v {
+			subitems[i] = SubItem{Key: fmt.Sprint(i), Value: &JSONRenderer{value: e}}
+		}
+		return subitems
+	}
+	return nil
+}
@@ -4,7 +4,21 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
-type StringRenderer types.AttributeValueMemberS
+type StringRenderer struct {
+	Value string
+
+	// json renders the decoded value of strings which contain a JSON object or array, and is nil otherwise
+	json *JSONRenderer
+}
+
+// newStringRenderer returns a renderer of the string, decoding it once if it contains a JSON object or array.
+func newStringRenderer(value string) *StringRenderer {
+	sr := &StringRenderer{Value: value}
+	if v, isJSON := parseJSON(value); isJSON {
+		sr.json = &JSONRenderer{value: v}
+	}
+	return sr
+}
 
 func (sr *StringRenderer) TypeName() string {
 	return "S"
@@ -15,10 +29,17 @@ func (sr *StringRenderer) StringValue() string {
 }
 
 func (sr *StringRenderer) MetaInfo() string {
+	if sr.json != nil {
+		return "(JSON " + sr.json.TypeName() + ")"
+	}
 	return ""
 }
 
+// SubItems returns the decoded value of strings which contain a JSON object or array.
 func (sr *StringRenderer) SubItems() []SubItem {
+	if sr.json != nil {
+		return sr.json.SubItems()
+	}
 	return nil
 }
 
@@ -49,7 +49,7 @@ func newNumberSetRenderer(v *types.AttributeValueMemberNS) *GenericRenderer {
 func newStringSetRenderer(v *types.AttributeValueMemberSS) *GenericRenderer {
 	vs := make([]Renderer, len(v.Value))
 	for i, s := range v.Value {
-		vs[i] = &StringRenderer{Value: s}
+		vs[i] = newStringRenderer(s)
 	}
 	return &GenericRenderer{typeName: "SS", subitemValue: vs}
 }
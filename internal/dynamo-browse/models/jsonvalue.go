@@ -0,0 +1,305 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/pkg/errors"
+)
+
+// ItemToJSONValue converts an item to a value which can be encoded as JSON.  Numbers are kept as json.Number to
+// avoid losing precision.  Sets are converted to arrays and binary values to base64 strings.
+func ItemToJSONValue(item Item) map[string]any {
+	m := make(map[string]any, len(item))
+	for k, v := range item {
+		m[k] = AttributeValueToJSONValue(v)
+	}
+	return m
+}
+
+// AttributeValueToJSONValue converts an attribute value to a value which can be encoded as JSON.
+func AttributeValueToJSONValue(value types.AttributeValue) any {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return v.Value
+	case *types.AttributeValueMemberN:
+		return json.Number(v.Value)
+	case *types.AttributeValueMemberBOOL:
+		return v.Value
+	case *types.AttributeValueMemberNULL:
+		return nil
+	case *types.AttributeValueMemberB:
+		return v.Value
+	case *types.AttributeValueMemberL:
+		list := make([]any, len(v.Value))
+		for i, e := range v.Value {
+			list[i] = AttributeValueToJSONValue(e)
+		}
+		return list
+	case *types.AttributeValueMemberM:
+		m := make(map[string]any, len(v.Value))
+		for k, e := range v.Value {
+			m[k] = AttributeValueToJSONValue(e)
+		}
+		return m
+	case *types.AttributeValueMemberSS:
+		return v.Value
+	case *types.AttributeValueMemberNS:
+		list := make([]json.Number, len(v.Value))
+		for i, e := range v.Value {
+			list[i] = json.Number(e)
+		}
+		return list
+	case *types.AttributeValueMemberBS:
+		return v.Value
+	}
+	return nil
+}
+
+// ItemFromJSONValue converts a JSON object, decoded using json.Decoder.UseNumber, into an item.
+func ItemFromJSONValue(m map[string]any) (Item, error) {
+	item := make(Item, len(m))
+	for k, v := range m {
+		av, err := jsonToAttributeValue(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "attribute %v", k)
+		}
+		item[k] = av
+	}
+	return item, nil
+}
+
+func jsonToAttributeValue(value any) (types.AttributeValue, error) {
+	switch v := value.(type) {
+	case nil:
+		return &types.AttributeValueMemberNULL{Value: true}, nil
+	case string:
+		return &types.AttributeValueMemberS{Value: v}, nil
+	case json.Number:
+		return &types.AttributeValueMemberN{Value: v.String()}, nil
+	case bool:
+		return &types.AttributeValueMemberBOOL{Value: v}, nil
+	case []any:
+		list := make([]types.AttributeValue, len(v))
+		for i, e := range v {
+			av, err := jsonToAttributeValue(e)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = av
+		}
+		return &types.AttributeValueMemberL{Value: list}, nil
+	case map[string]any:
+		m := make(map[string]types.AttributeValue, len(v))
+		for k, e := range v {
+			av, err := jsonToAttributeValue(e)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = av
+		}
+		return &types.AttributeValueMemberM{Value: m}, nil
+	}
+	return nil, errors.Errorf("unsupported JSON value: %T", value)
+}
+
+// ItemLosesTypesAsJSONValue returns true if the item has attributes whose types cannot be recovered once the item
+// is converted using ItemToJSONValue, such as binary values and sets.
+func ItemLosesTypesAsJSONValue(item Item) bool {
+	for _, v := range item {
+		if attributeValueLosesTypeAsJSONValue(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func attributeValueLosesTypeAsJSONValue(value types.AttributeValue) bool {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberB, *types.AttributeValueMemberSS, *types.AttributeValueMemberNS, *types.AttributeValueMemberBS:
+		return true
+	case *types.AttributeValueMemberL:
+		for _, e := range v.Value {
+			if attributeValueLosesTypeAsJSONValue(e) {
+				return true
+			}
+		}
+	case *types.AttributeValueMemberM:
+		for _, e := range v.Value {
+			if attributeValueLosesTypeAsJSONValue(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ItemToDynamoDBJSON converts an item to a value which can be encoded using DynamoDB JSON, in which each
+// attribute value is an object keyed by its type, such as {"S": "hello"}.  Unlike ItemToJSONValue, the types of
+// all attributes are preserved.
+func ItemToDynamoDBJSON(item Item) map[string]any {
+	m := make(map[string]any, len(item))
+	for k, v := range item {
+		m[k] = attributeValueToDynamoDBJSON(v)
+	}
+	return m
+}
+
+func attributeValueToDynamoDBJSON(value types.AttributeValue) map[string]any {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return map[string]any{"S": v.Value}
+	case *types.AttributeValueMemberN:
+		return map[string]any{"N": v.Value}
+	case *types.AttributeValueMemberBOOL:
+		return map[string]any{"BOOL": v.Value}
+	case *types.AttributeValueMemberNULL:
+		return map[string]any{"NULL": true}
+	case *types.AttributeValueMemberB:
+		return map[string]any{"B": v.Value}
+	case *types.AttributeValueMemberL:
+		list := make([]any, len(v.Value))
+		for i, e := range v.Value {
+			list[i] = attributeValueToDynamoDBJSON(e)
+		}
+		return map[string]any{"L": list}
+	case *types.AttributeValueMemberM:
+		m := make(map[string]any, len(v.Value))
+		for k, e := range v.Value {
+			m[k] = attributeValueToDynamoDBJSON(e)
+		}
+		return map[string]any{"M": m}
+	case *types.AttributeValueMemberSS:
+		return map[string]any{"SS": v.Value}
+	case *types.AttributeValueMemberNS:
+		return map[string]any{"NS": v.Value}
+	case *types.AttributeValueMemberBS:
+		return map[string]any{"BS": v.Value}
+	}
+	return nil
+}
+
+// ItemFromDynamoDBJSON converts a DynamoDB JSON object, decoded using json.Decoder.UseNumber, into an item.
+func ItemFromDynamoDBJSON(m map[string]any) (Item, error) {
+	item := make(Item, len(m))
+	for k, v := range m {
+		av, err := dynamoDBJSONToAttributeValue(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "attribute %v", k)
+		}
+		item[k] = av
+	}
+	return item, nil
+}
+
+func dynamoDBJSONToAttributeValue(value any) (types.AttributeValue, error) {
+	typed, isObject := value.(map[string]any)
+	if !isObject || len(typed) != 1 {
+		return nil, errors.New("expected an object with a single type, such as {\"S\": \"value\"}")
+	}
+
+	for typeName, v := range typed {
+		switch typeName {
+		case "S":
+			if s, isString := v.(string); isString {
+				return &types.AttributeValueMemberS{Value: s}, nil
+			}
+		case "N":
+			// Numbers are written as strings, but are also accepted as JSON numbers
+			switch n := v.(type) {
+			case string:
+				return &types.AttributeValueMemberN{Value: n}, nil
+			case json.Number:
+				return &types.AttributeValueMemberN{Value: n.String()}, nil
+			}
+		case "BOOL":
+			if b, isBool := v.(bool); isBool {
+				return &types.AttributeValueMemberBOOL{Value: b}, nil
+			}
+		case "NULL":
+			if b, isBool := v.(bool); isBool && b {
+				return &types.AttributeValueMemberNULL{Value: true}, nil
+			}
+		case "B":
+			if s, isString := v.(string); isString {
+				bts, err := base64.StdEncoding.DecodeString(s)
+				if err != nil {
+					return nil, errors.Wrap(err, "invalid binary value")
+				}
+				return &types.AttributeValueMemberB{Value: bts}, nil
+			}
+		case "L":
+			if elems, isList := v.([]any); isList {
+				list := make([]types.AttributeValue, len(elems))
+				for i, e := range elems {
+					av, err := dynamoDBJSONToAttributeValue(e)
+					if err != nil {
+						return nil, errors.Wrapf(err, "element %d", i)
+					}
+					list[i] = av
+				}
+				return &types.AttributeValueMemberL{Value: list}, nil
+			}
+		case "M":
+			if fields, isMap := v.(map[string]any); isMap {
+				m := make(map[string]types.AttributeValue, len(fields))
+				for k, e := range fields {
+					av, err := dynamoDBJSONToAttributeValue(e)
+					if err != nil {
+						return nil, errors.Wrapf(err, "attribute %v", k)
+					}
+					m[k] = av
+				}
+				return &types.AttributeValueMemberM{Value: m}, nil
+			}
+		case "SS", "NS", "BS":
+			if elems, isStrings := dynamoDBJSONStrings(v, typeName == "NS"); isStrings {
+				switch typeName {
+				case "SS":
+					return &types.AttributeValueMemberSS{Value: elems}, nil
+				case "NS":
+					return &types.AttributeValueMemberNS{Value: elems}, nil
+				}
+
+				bs := make([][]byte, len(elems))
+				for i, e := range elems {
+					bts, err := base64.StdEncoding.DecodeString(e)
+					if err != nil {
+						return nil, errors.Wrap(err, "invalid binary value")
+					}
+					bs[i] = bts
+				}
+				return &types.AttributeValueMemberBS{Value: bs}, nil
+			}
+		default:
+			return nil, errors.Errorf("unrecognised type '%v'", typeName)
+		}
+		return nil, errors.Errorf("invalid value for type '%v': %v", typeName, v)
+	}
+	return nil, nil
+}
+
+// dynamoDBJSONStrings returns the elements of a set.  If allowNumbers is true, elements can also be JSON numbers.
+func dynamoDBJSONStrings(value any, allowNumbers bool) ([]string, bool) {
+	elems, isList := value.([]any)
+	if !isList {
+		return nil, false
+	}
+
+	strs := make([]string, len(elems))
+	for i, e := range elems {
+		switch s := e.(type) {
+		case string:
+			strs[i] = s
+		case json.Number:
+			if !allowNumbers {
+				return nil, false
+			}
+			strs[i] = s.String()
+		default:
+			return nil, false
+		}
+	}
+	return strs, true
+}
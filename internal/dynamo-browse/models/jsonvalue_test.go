@@ -0,0 +1,82 @@
+package models_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemToDynamoDBJSON(t *testing.T) {
+	t.Run("should preserve the types of all attributes", func(t *testing.T) {
+		item := models.Item{
+			"s":    &types.AttributeValueMemberS{Value: "hello"},
+			"n":    &types.AttributeValueMemberN{Value: "12345678901234567890.123"},
+			"b":    &types.AttributeValueMemberB{Value: []byte{0x00, 0xff}},
+			"bool": &types.AttributeValueMemberBOOL{Value: true},
+			"null": &types.AttributeValueMemberNULL{Value: true},
+			"ss":   &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+			"ns":   &types.AttributeValueMemberNS{Value: []string{"1", "2.5"}},
+			"bs":   &types.AttributeValueMemberBS{Value: [][]byte{{0x01}, {0x02}}},
+			"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberS{Value: "x"},
+				&types.AttributeValueMemberSS{Value: []string{"y"}},
+			}},
+			"m": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"b": &types.AttributeValueMemberB{Value: []byte("bin")},
+			}},
+		}
+
+		bts, err := json.Marshal(models.ItemToDynamoDBJSON(item))
+		assert.NoError(t, err)
+
+		var m map[string]any
+		dec := json.NewDecoder(bytes.NewReader(bts))
+		dec.UseNumber()
+		assert.NoError(t, dec.Decode(&m))
+
+		decoded, err := models.ItemFromDynamoDBJSON(m)
+		assert.NoError(t, err)
+		assert.Equal(t, item, decoded)
+	})
+}
+
+func TestItemFromDynamoDBJSON(t *testing.T) {
+	t.Run("should accept numbers as JSON numbers", func(t *testing.T) {
+		item, err := models.ItemFromDynamoDBJSON(map[string]any{
+			"n":  map[string]any{"N": json.Number("12")},
+			"ns": map[string]any{"NS": []any{json.Number("1"), "2"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, models.Item{
+			"n":  &types.AttributeValueMemberN{Value: "12"},
+			"ns": &types.AttributeValueMemberNS{Value: []string{"1", "2"}},
+		}, item)
+	})
+
+	t.Run("should reject untyped values", func(t *testing.T) {
+		_, err := models.ItemFromDynamoDBJSON(map[string]any{"s": "hello"})
+		assert.Error(t, err)
+
+		_, err = models.ItemFromDynamoDBJSON(map[string]any{"s": map[string]any{"X": "hello"}})
+		assert.Error(t, err)
+
+		_, err = models.ItemFromDynamoDBJSON(map[string]any{"ss": map[string]any{"SS": []any{json.Number("1")}}})
+		assert.Error(t, err)
+	})
+}
+
+func TestItemLosesTypesAsJSONValue(t *testing.T) {
+	assert.False(t, models.ItemLosesTypesAsJSONValue(models.Item{
+		"s": &types.AttributeValueMemberS{Value: "hello"},
+		"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberN{Value: "1"}}},
+	}))
+	assert.True(t, models.ItemLosesTypesAsJSONValue(models.Item{
+		"m": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"ns": &types.AttributeValueMemberNS{Value: []string{"1"}},
+		}},
+	}))
+}
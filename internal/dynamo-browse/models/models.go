@@ -5,6 +5,11 @@ import "sort"
 type ResultSet struct {
 	TableInfo *TableInfo
 	Query     Queryable
+
+	// ReadOnly is set if the items do not hold all their attributes, such as the results of a PartiQL SELECT with
+	// a projection.  Putting these items would remove the missing attributes, so they cannot be put or deleted.
+	ReadOnly bool
+
 	//Columns    []string
 	items      []Item
 	attributes []ItemAttribute
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	partiQLTableNameRegexp = regexp.MustCompile(`(?i)\b(?:FROM|INTO|UPDATE)\s+(?:"([^"]+)"|([A-Za-z0-9_\-]+))`)
+	partiQLReturningRegexp = regexp.MustCompile(`(?i)\bRETURNING\s+(?:ALL|MODIFIED)\s+(?:OLD|NEW)\s+\*`)
+	partiQLSelectAllRegexp = regexp.MustCompile(`(?i)^\s*SELECT\s+\*\s+FROM\b`)
+	partiQLFromIndexRegexp = regexp.MustCompile(`(?i)\bFROM\s+(?:"[^"]+"|[A-Za-z0-9_\-]+)\.`)
+)
+
+// PartiQLStatement is a PartiQL statement that can be executed against a table.
+type PartiQLStatement struct {
+	Statement string
+}
+
+func (ps *PartiQLStatement) String() string {
+	return ps.Statement
+}
+
+func (ps *PartiQLStatement) Plan(tableInfo *TableInfo) (*QueryExecutionPlan, error) {
+	return nil, errors.New("PartiQL statements cannot be planned")
+}
+
+// IsSelect returns true if the statement is a SELECT statement.
+func (ps *PartiQLStatement) IsSelect() bool {
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(ps.Statement)), "SELECT")
+}
+
+// SelectsAllAttributes returns true if the statement is a SELECT statement which returns all the attributes of each
+// item, such as "SELECT * FROM users".  Statements projecting some attributes, or selecting from an index which may
+// only project some attributes, return false.
+func (ps *PartiQLStatement) SelectsAllAttributes() bool {
+	return partiQLSelectAllRegexp.MatchString(ps.Statement) && !partiQLFromIndexRegexp.MatchString(ps.Statement)
+}
+
+// HasReturning returns true if the statement has a RETURNING clause, such as "RETURNING ALL OLD *".  Only
+// statements with a RETURNING clause return the items they modify.
+func (ps *PartiQLStatement) HasReturning() bool {
+	return partiQLReturningRegexp.MatchString(ps.Statement)
+}
+
+// TableName returns the name of the table the statement operates on.
+func (ps *PartiQLStatement) TableName() (string, error) {
+	m := partiQLTableNameRegexp.FindStringSubmatch(ps.Statement)
+	if m == nil {
+		return "", errors.New("cannot determine table of statement")
+	}
+	if m[1] != "" {
+		return m[1], nil
+	}
+	return m[2], nil
+}
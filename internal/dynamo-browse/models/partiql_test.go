@@ -0,0 +1,41 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartiQLStatement_TableName(t *testing.T) {
+	scenarios := []struct {
+		statement string
+		tableName string
+		isSelect  bool
+		returning bool
+		selectAll bool
+	}{
+		{statement: `SELECT * FROM "user-accounts"`, tableName: "user-accounts", isSelect: true, selectAll: true},
+		{statement: `select * from users where pk = 'abc'`, tableName: "users", isSelect: true, selectAll: true},
+		{statement: `SELECT pk, name FROM users`, tableName: "users", isSelect: true},
+		{statement: `SELECT * FROM "my.table"."byEmail" WHERE email = 'x'`, tableName: "my.table", isSelect: true},
+		{statement: `UPDATE "users" SET age = 23 WHERE pk = 'abc'`, tableName: "users", isSelect: false},
+		{statement: `INSERT INTO users VALUE {'pk': 'abc'}`, tableName: "users", isSelect: false},
+		{statement: `DELETE FROM users WHERE pk = 'abc'`, tableName: "users", isSelect: false},
+		{statement: `DELETE FROM users WHERE pk = 'abc' RETURNING ALL OLD *`, tableName: "users", isSelect: false, returning: true},
+		{statement: `update users set age = 23 where pk = 'abc' returning modified new *`, tableName: "users", isSelect: false, returning: true},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.statement, func(t *testing.T) {
+			stmt := &models.PartiQLStatement{Statement: scenario.statement}
+
+			tableName, err := stmt.TableName()
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.tableName, tableName)
+			assert.Equal(t, scenario.isSelect, stmt.IsSelect())
+			assert.Equal(t, scenario.returning, stmt.HasReturning())
+			assert.Equal(t, scenario.selectAll, stmt.SelectsAllAttributes())
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package models
+
+// SavedQuery is a named query saved for a particular table
+type SavedQuery struct {
+	Name      string `json:"name"`
+	TableName string `json:"table"`
+	Query     string `json:"query"`
+
+	// IsPartiQL is true if the query is a PartiQL statement, as opposed to a query expression
+	IsPartiQL bool `json:"partiql,omitempty"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Search finds attribute values matching a search term.  The term is matched against the displayed value
+// of each attribute.
+type Search struct {
+	Expr   string
+	column string
+	re     *regexp.Regexp
+}
+
+// ParseSearch parses a search expression.  The expression is case-insensitive text, or a regular expression
+// if surrounded by slashes, such as "/^user-\d+$/".  The search can be restricted to a single column by
+// prefixing the expression with the column name and a colon, such as "pk:abc".
+func ParseSearch(expr string, columns []string) (*Search, error) {
+	search := &Search{Expr: expr}
+
+	if colName, term, hasColumn := strings.Cut(expr, ":"); hasColumn {
+		for _, c := range columns {
+			if c == colName {
+				search.column = colName
+				expr = term
+				break
+			}
+		}
+	}
+
+	if expr == "" {
+		return nil, errors.New("empty search term")
+	}
+
+	if len(expr) >= 2 && strings.HasPrefix(expr, "/") && strings.HasSuffix(expr, "/") {
+		re, err := regexp.Compile(expr[1 : len(expr)-1])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid regular expression")
+		}
+		search.re = re
+	} else {
+		search.re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(expr))
+	}
+	return search, nil
+}
+
+// Column returns the column the search is restricted to, or an empty string if all columns are searched.
+func (s *Search) Column() string {
+	return s.column
+}
+
+// MatchesAttribute returns true if the value of the attribute matches the search.
+func (s *Search) MatchesAttribute(item Item, attr string) bool {
+	if s.column != "" && s.column != attr {
+		return false
+	}
+
+	r := item.Renderer(attr)
+	if r == nil {
+		return false
+	}
+	return s.re.MatchString(r.StringValue())
+}
+
+// MatchesItem returns true if any of the columns of the item matches the search.
+func (s *Search) MatchesItem(item Item, columns []string) bool {
+	if s.column != "" {
+		return s.MatchesAttribute(item, s.column)
+	}
+
+	for _, c := range columns {
+		if s.MatchesAttribute(item, c) {
+			return true
+		}
+	}
+	return false
+}
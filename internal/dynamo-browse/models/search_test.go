@@ -0,0 +1,54 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	columns := []string{"pk", "sk", "name"}
+	item := models.Item{
+		"pk":   &types.AttributeValueMemberS{Value: "user-123"},
+		"sk":   &types.AttributeValueMemberN{Value: "42"},
+		"name": &types.AttributeValueMemberS{Value: "Alice Smith"},
+	}
+
+	scenarios := []struct {
+		expr        string
+		wantMatches map[string]bool
+	}{
+		{expr: "smith", wantMatches: map[string]bool{"name": true}},
+		{expr: "2", wantMatches: map[string]bool{"pk": true, "sk": true}},
+		{expr: "pk:2", wantMatches: map[string]bool{"pk": true}},
+		{expr: "name:user", wantMatches: map[string]bool{}},
+		{expr: `/^user-\d+$/`, wantMatches: map[string]bool{"pk": true}},
+		{expr: `/^Alice/`, wantMatches: map[string]bool{"name": true}},
+		{expr: `/^alice/`, wantMatches: map[string]bool{}},
+		{expr: "other:user", wantMatches: map[string]bool{}},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.expr, func(t *testing.T) {
+			search, err := models.ParseSearch(scenario.expr, columns)
+			assert.NoError(t, err)
+
+			for _, c := range columns {
+				assert.Equal(t, scenario.wantMatches[c], search.MatchesAttribute(item, c), c)
+			}
+			assert.Equal(t, len(scenario.wantMatches) > 0, search.MatchesItem(item, columns))
+		})
+	}
+
+	t.Run("should return error for invalid regular expressions", func(t *testing.T) {
+		_, err := models.ParseSearch("/a(/", columns)
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error for empty terms", func(t *testing.T) {
+		_, err := models.ParseSearch("pk:", columns)
+		assert.Error(t, err)
+	})
+}
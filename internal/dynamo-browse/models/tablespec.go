@@ -0,0 +1,100 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/pkg/errors"
+)
+
+// TableSpec describes the schema of a table, as used when creating or cloning it.
+type TableSpec struct {
+	Name       string
+	Keys       KeySpec
+	OnDemand   bool
+	GSIs       []IndexSpec
+	LSIs       []IndexSpec
+	ReadUnits  int64
+	WriteUnits int64
+}
+
+type KeySpec struct {
+	PartitionKey KeyAttributeSpec
+	SortKey      *KeyAttributeSpec
+}
+
+type KeyAttributeSpec struct {
+	Name string
+	Type types.ScalarAttributeType
+}
+
+type IndexSpec struct {
+	Name string
+	Keys KeySpec
+
+	// Projection is the attributes projected into the index.  All attributes are projected if nil.
+	Projection *types.Projection
+
+	// ReadUnits and WriteUnits are the provisioned throughput of a global secondary index of a table with
+	// provisioned capacity.  The throughput of the table is used if these are zero.
+	ReadUnits  int64
+	WriteUnits int64
+}
+
+// ParseKeyAttributeSpec parses a key attribute of the form "name:type", where type is one of S, N or B.
+// If type is not specified, it defaults to S.
+func ParseKeyAttributeSpec(s string) (KeyAttributeSpec, error) {
+	name, attrType, hasType := strings.Cut(s, ":")
+	if name == "" {
+		return KeyAttributeSpec{}, errors.Errorf("key attribute '%v' has no name", s)
+	}
+	if !hasType {
+		return KeyAttributeSpec{Name: name, Type: types.ScalarAttributeTypeS}, nil
+	}
+
+	switch strings.ToUpper(attrType) {
+	case "S":
+		return KeyAttributeSpec{Name: name, Type: types.ScalarAttributeTypeS}, nil
+	case "N":
+		return KeyAttributeSpec{Name: name, Type: types.ScalarAttributeTypeN}, nil
+	case "B":
+		return KeyAttributeSpec{Name: name, Type: types.ScalarAttributeTypeB}, nil
+	}
+	return KeyAttributeSpec{}, errors.Errorf("key attribute '%v' has unrecognised type: %v", name, attrType)
+}
+
+// ParseIndexSpec parses a global secondary index of the form "name=pk:type[,sk:type]".
+func ParseIndexSpec(s string) (IndexSpec, error) {
+	name, keys, hasKeys := strings.Cut(s, "=")
+	if name == "" || !hasKeys {
+		return IndexSpec{}, errors.Errorf("index '%v' expected to be of the form name=pk:type[,sk:type]", s)
+	}
+
+	keyParts := strings.Split(keys, ",")
+	if len(keyParts) > 2 {
+		return IndexSpec{}, errors.Errorf("index '%v' can have at most two keys", name)
+	}
+
+	pk, err := ParseKeyAttributeSpec(keyParts[0])
+	if err != nil {
+		return IndexSpec{}, err
+	}
+
+	indexSpec := IndexSpec{Name: name, Keys: KeySpec{PartitionKey: pk}}
+	if len(keyParts) == 2 {
+		sk, err := ParseKeyAttributeSpec(keyParts[1])
+		if err != nil {
+			return IndexSpec{}, err
+		}
+		indexSpec.Keys.SortKey = &sk
+	}
+	return indexSpec, nil
+}
+
+func (ks KeySpec) String() string {
+	if ks.SortKey != nil {
+		return fmt.Sprintf("%v:%v,%v:%v", ks.PartitionKey.Name, ks.PartitionKey.Type, ks.SortKey.Name, ks.SortKey.Type)
+	}
+	return fmt.Sprintf("%v:%v", ks.PartitionKey.Name, ks.PartitionKey.Type)
+}
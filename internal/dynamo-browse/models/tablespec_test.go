@@ -0,0 +1,55 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeyAttributeSpec(t *testing.T) {
+	scenarios := []struct {
+		spec     string
+		expected models.KeyAttributeSpec
+	}{
+		{spec: "pk", expected: models.KeyAttributeSpec{Name: "pk", Type: types.ScalarAttributeTypeS}},
+		{spec: "pk:S", expected: models.KeyAttributeSpec{Name: "pk", Type: types.ScalarAttributeTypeS}},
+		{spec: "sk:n", expected: models.KeyAttributeSpec{Name: "sk", Type: types.ScalarAttributeTypeN}},
+		{spec: "data:B", expected: models.KeyAttributeSpec{Name: "data", Type: types.ScalarAttributeTypeB}},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.spec, func(t *testing.T) {
+			spec, err := models.ParseKeyAttributeSpec(scenario.spec)
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expected, spec)
+		})
+	}
+
+	t.Run("should return error if type is unrecognised", func(t *testing.T) {
+		_, err := models.ParseKeyAttributeSpec("pk:BOOL")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseIndexSpec(t *testing.T) {
+	t.Run("should parse index with partition key only", func(t *testing.T) {
+		spec, err := models.ParseIndexSpec("byEmail=email:S")
+		assert.NoError(t, err)
+		assert.Equal(t, "byEmail", spec.Name)
+		assert.Equal(t, "email:S", spec.Keys.String())
+	})
+
+	t.Run("should parse index with partition and sort key", func(t *testing.T) {
+		spec, err := models.ParseIndexSpec("byAge=group,age:N")
+		assert.NoError(t, err)
+		assert.Equal(t, "byAge", spec.Name)
+		assert.Equal(t, "group:S,age:N", spec.Keys.String())
+	})
+
+	t.Run("should return error if index has no name", func(t *testing.T) {
+		_, err := models.ParseIndexSpec("email:S")
+		assert.Error(t, err)
+	})
+}
@@ -9,18 +9,46 @@ import (
 	"github.com/lmika/audax/internal/common/sliceutils"
 	"github.com/lmika/audax/internal/dynamo-browse/models"
 	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+// tableWaitDuration is the maximum time to wait for a table to be created or deleted
+const tableWaitDuration = 5 * time.Minute
+
+const (
+	// maxBatchWriteAttempts is the number of times a batch write is attempted before unprocessed items are
+	// treated as an error
+	maxBatchWriteAttempts = 8
+
+	// batchWriteRetryDelay is the delay before the first retry of unprocessed items.  This doubles on each retry.
+	batchWriteRetryDelay = 50 * time.Millisecond
 )
 
 type Provider struct {
+	mutex  *sync.RWMutex
 	client *dynamodb.Client
 }
 
 func NewProvider(client *dynamodb.Client) *Provider {
-	return &Provider{client: client}
+	return &Provider{client: client, mutex: new(sync.RWMutex)}
+}
+
+// SetClient replaces the client used by the provider, such as when the user switches profile or region.
+func (p *Provider) SetClient(client *dynamodb.Client) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.client = client
+}
+
+func (p *Provider) currentClient() *dynamodb.Client {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.client
 }
 
 func (p *Provider) ListTables(ctx context.Context) ([]string, error) {
-	out, err := p.client.ListTables(ctx, &dynamodb.ListTablesInput{})
+	out, err := p.currentClient().ListTables(ctx, &dynamodb.ListTablesInput{})
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot list tables")
 	}
@@ -29,7 +57,7 @@ func (p *Provider) ListTables(ctx context.Context) ([]string, error) {
 }
 
 func (p *Provider) DescribeTable(ctx context.Context, tableName string) (*models.TableInfo, error) {
-	out, err := p.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+	out, err := p.currentClient().DescribeTable(ctx, &dynamodb.DescribeTableInput{
 		TableName: aws.String(tableName),
 	})
 	if err != nil {
@@ -55,7 +83,7 @@ func (p *Provider) DescribeTable(ctx context.Context, tableName string) (*models
 }
 
 func (p *Provider) PutItem(ctx context.Context, name string, item models.Item) error {
-	_, err := p.client.PutItem(ctx, &dynamodb.PutItemInput{
+	_, err := p.currentClient().PutItem(ctx, &dynamodb.PutItemInput{
 		TableName: aws.String(name),
 		Item:      item,
 	})
@@ -78,20 +106,51 @@ func (p *Provider) batchPutItems(ctx context.Context, name string, items []model
 		}
 
 		itemsInThisRequest := items[s:f]
+		if len(itemsInThisRequest) == 0 {
+			break
+		}
+
 		writeRequests := sliceutils.Map(itemsInThisRequest, func(item models.Item) types.WriteRequest {
 			return types.WriteRequest{PutRequest: &types.PutRequest{Item: item}}
 		})
 
-		_, err := p.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
-			RequestItems: map[string][]types.WriteRequest{
-				name: writeRequests,
-			},
+		if err := p.batchWrite(ctx, name, writeRequests); err != nil {
+			return errors.Wrapf(err, "unable to put page %v of batch puts", rn)
+		}
+	}
+	return nil
+}
+
+// batchWrite executes the write requests as a single batch, retrying unprocessed requests with backoff.  An error
+// is returned if requests remain unprocessed after all attempts.
+func (p *Provider) batchWrite(ctx context.Context, name string, writeRequests []types.WriteRequest) error {
+	requestItems := map[string][]types.WriteRequest{name: writeRequests}
+	retryDelay := batchWriteRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		out, err := p.currentClient().BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: requestItems,
 		})
 		if err != nil {
-			errors.Wrapf(err, "unable to put page %v of back puts", rn)
+			return err
 		}
+
+		requestItems = out.UnprocessedItems
+		unprocessed := len(requestItems[name])
+		if unprocessed == 0 {
+			return nil
+		} else if attempt >= maxBatchWriteAttempts {
+			return errors.Errorf("%d of %d items were not written to table %v after %d attempts",
+				unprocessed, len(writeRequests), name, attempt)
+		}
+
+		select {
+		case <-time.After(retryDelay):
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "%d of %d items were not written to table %v", unprocessed, len(writeRequests), name)
+		}
+		retryDelay *= 2
 	}
-	return nil
 }
 
 func (p *Provider) ScanItems(ctx context.Context, tableName string, filterExpr *expression.Expression, maxItems int) ([]models.Item, error) {
@@ -105,7 +164,7 @@ func (p *Provider) ScanItems(ctx context.Context, tableName string, filterExpr *
 		input.ExpressionAttributeValues = filterExpr.Values()
 	}
 
-	paginator := dynamodb.NewScanPaginator(p.client, input)
+	paginator := dynamodb.NewScanPaginator(p.currentClient(), input)
 
 	items := make([]models.Item, 0)
 
@@ -127,10 +186,244 @@ outer:
 	return items, nil
 }
 
+// ScanPages scans the entire table, calling onPage with the items of each page.  Scanning stops if onPage
+// returns an error.
+func (p *Provider) ScanPages(ctx context.Context, tableName string, onPage func(items []models.Item) error) error {
+	paginator := dynamodb.NewScanPaginator(p.currentClient(), &dynamodb.ScanInput{
+		TableName: aws.String(tableName),
+	})
+
+	for paginator.HasMorePages() {
+		res, err := paginator.NextPage(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "cannot execute scan on table %v", tableName)
+		}
+		if len(res.Items) == 0 {
+			continue
+		}
+
+		items := make([]models.Item, len(res.Items))
+		for i, itm := range res.Items {
+			items[i] = itm
+		}
+		if err := onPage(items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *Provider) DeleteItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) error {
-	_, err := p.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+	_, err := p.currentClient().DeleteItem(ctx, &dynamodb.DeleteItemInput{
 		TableName: aws.String(tableName),
 		Key:       key,
 	})
 	return errors.Wrap(err, "could not delete item")
 }
+
+func (p *Provider) DescribeTableSpec(ctx context.Context, tableName string) (*models.TableSpec, error) {
+	out, err := p.currentClient().DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot describe table %v", tableName)
+	}
+
+	attrTypes := make(map[string]types.ScalarAttributeType)
+	for _, attrDef := range out.Table.AttributeDefinitions {
+		attrTypes[aws.ToString(attrDef.AttributeName)] = attrDef.AttributeType
+	}
+
+	spec := &models.TableSpec{
+		Name: aws.ToString(out.Table.TableName),
+		Keys: keySchemaToKeySpec(out.Table.KeySchema, attrTypes),
+	}
+	if out.Table.BillingModeSummary != nil && out.Table.BillingModeSummary.BillingMode == types.BillingModePayPerRequest {
+		spec.OnDemand = true
+	} else if out.Table.ProvisionedThroughput != nil {
+		spec.ReadUnits = aws.ToInt64(out.Table.ProvisionedThroughput.ReadCapacityUnits)
+		spec.WriteUnits = aws.ToInt64(out.Table.ProvisionedThroughput.WriteCapacityUnits)
+	}
+
+	for _, gsi := range out.Table.GlobalSecondaryIndexes {
+		indexSpec := models.IndexSpec{
+			Name:       aws.ToString(gsi.IndexName),
+			Keys:       keySchemaToKeySpec(gsi.KeySchema, attrTypes),
+			Projection: gsi.Projection,
+		}
+		if !spec.OnDemand && gsi.ProvisionedThroughput != nil {
+			indexSpec.ReadUnits = aws.ToInt64(gsi.ProvisionedThroughput.ReadCapacityUnits)
+			indexSpec.WriteUnits = aws.ToInt64(gsi.ProvisionedThroughput.WriteCapacityUnits)
+		}
+		spec.GSIs = append(spec.GSIs, indexSpec)
+	}
+	for _, lsi := range out.Table.LocalSecondaryIndexes {
+		spec.LSIs = append(spec.LSIs, models.IndexSpec{
+			Name:       aws.ToString(lsi.IndexName),
+			Keys:       keySchemaToKeySpec(lsi.KeySchema, attrTypes),
+			Projection: lsi.Projection,
+		})
+	}
+
+	return spec, nil
+}
+
+func (p *Provider) CreateTable(ctx context.Context, spec models.TableSpec) error {
+	attrDefs := make(map[string]types.ScalarAttributeType)
+	addKeyAttrs := func(ks models.KeySpec) {
+		attrDefs[ks.PartitionKey.Name] = ks.PartitionKey.Type
+		if ks.SortKey != nil {
+			attrDefs[ks.SortKey.Name] = ks.SortKey.Type
+		}
+	}
+
+	addKeyAttrs(spec.Keys)
+	for _, gsi := range spec.GSIs {
+		addKeyAttrs(gsi.Keys)
+	}
+	for _, lsi := range spec.LSIs {
+		addKeyAttrs(lsi.Keys)
+	}
+
+	in := &dynamodb.CreateTableInput{
+		TableName: aws.String(spec.Name),
+		KeySchema: keySpecToKeySchema(spec.Keys),
+	}
+	for name, attrType := range attrDefs {
+		in.AttributeDefinitions = append(in.AttributeDefinitions, types.AttributeDefinition{
+			AttributeName: aws.String(name),
+			AttributeType: attrType,
+		})
+	}
+
+	if spec.OnDemand {
+		in.BillingMode = types.BillingModePayPerRequest
+	} else {
+		in.BillingMode = types.BillingModeProvisioned
+		in.ProvisionedThroughput = &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(spec.ReadUnits),
+			WriteCapacityUnits: aws.Int64(spec.WriteUnits),
+		}
+	}
+
+	for _, gsi := range spec.GSIs {
+		in.GlobalSecondaryIndexes = append(in.GlobalSecondaryIndexes, types.GlobalSecondaryIndex{
+			IndexName:             aws.String(gsi.Name),
+			KeySchema:             keySpecToKeySchema(gsi.Keys),
+			Projection:            indexProjection(gsi),
+			ProvisionedThroughput: indexThroughput(spec, gsi),
+		})
+	}
+	for _, lsi := range spec.LSIs {
+		in.LocalSecondaryIndexes = append(in.LocalSecondaryIndexes, types.LocalSecondaryIndex{
+			IndexName:  aws.String(lsi.Name),
+			KeySchema:  keySpecToKeySchema(lsi.Keys),
+			Projection: indexProjection(lsi),
+		})
+	}
+
+	if _, err := p.currentClient().CreateTable(ctx, in); err != nil {
+		return errors.Wrapf(err, "cannot create table %v", spec.Name)
+	}
+
+	if err := dynamodb.NewTableExistsWaiter(p.currentClient()).Wait(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(spec.Name),
+	}, tableWaitDuration); err != nil {
+		return errors.Wrapf(err, "table %v did not become active", spec.Name)
+	}
+	return nil
+}
+
+func (p *Provider) DeleteTable(ctx context.Context, tableName string) error {
+	if _, err := p.currentClient().DeleteTable(ctx, &dynamodb.DeleteTableInput{
+		TableName: aws.String(tableName),
+	}); err != nil {
+		return errors.Wrapf(err, "cannot delete table %v", tableName)
+	}
+
+	if err := dynamodb.NewTableNotExistsWaiter(p.currentClient()).Wait(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	}, tableWaitDuration); err != nil {
+		return errors.Wrapf(err, "table %v was not deleted", tableName)
+	}
+	return nil
+}
+
+// indexThroughput returns the provisioned throughput of a global secondary index.  Indexes of tables with
+// provisioned capacity use the throughput of the table unless the index has its own.
+func indexThroughput(spec models.TableSpec, gsi models.IndexSpec) *types.ProvisionedThroughput {
+	if spec.OnDemand {
+		return nil
+	}
+
+	throughput := &types.ProvisionedThroughput{
+		ReadCapacityUnits:  aws.Int64(spec.ReadUnits),
+		WriteCapacityUnits: aws.Int64(spec.WriteUnits),
+	}
+	if gsi.ReadUnits > 0 {
+		throughput.ReadCapacityUnits = aws.Int64(gsi.ReadUnits)
+	}
+	if gsi.WriteUnits > 0 {
+		throughput.WriteCapacityUnits = aws.Int64(gsi.WriteUnits)
+	}
+	return throughput
+}
+
+// indexProjection returns the projection of the index, which projects all attributes if not set.
+func indexProjection(index models.IndexSpec) *types.Projection {
+	if index.Projection != nil {
+		return index.Projection
+	}
+	return &types.Projection{ProjectionType: types.ProjectionTypeAll}
+}
+
+func keySchemaToKeySpec(keySchema []types.KeySchemaElement, attrTypes map[string]types.ScalarAttributeType) models.KeySpec {
+	var keySpec models.KeySpec
+	for _, ks := range keySchema {
+		attrName := aws.ToString(ks.AttributeName)
+		if ks.KeyType == types.KeyTypeHash {
+			keySpec.PartitionKey = models.KeyAttributeSpec{Name: attrName, Type: attrTypes[attrName]}
+		} else if ks.KeyType == types.KeyTypeRange {
+			keySpec.SortKey = &models.KeyAttributeSpec{Name: attrName, Type: attrTypes[attrName]}
+		}
+	}
+	return keySpec
+}
+
+func keySpecToKeySchema(keySpec models.KeySpec) []types.KeySchemaElement {
+	keySchema := []types.KeySchemaElement{
+		{AttributeName: aws.String(keySpec.PartitionKey.Name), KeyType: types.KeyTypeHash},
+	}
+	if keySpec.SortKey != nil {
+		keySchema = append(keySchema, types.KeySchemaElement{AttributeName: aws.String(keySpec.SortKey.Name), KeyType: types.KeyTypeRange})
+	}
+	return keySchema
+}
+
+func (p *Provider) ExecuteStatement(ctx context.Context, statement string, maxItems int) ([]models.Item, error) {
+	input := &dynamodb.ExecuteStatementInput{
+		Statement: aws.String(statement),
+	}
+
+	items := make([]models.Item, 0)
+	for {
+		out, err := p.currentClient().ExecuteStatement(ctx, input)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot execute statement")
+		}
+
+		for _, itm := range out.Items {
+			items = append(items, itm)
+			if len(items) >= maxItems {
+				return items, nil
+			}
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	return items, nil
+}
@@ -0,0 +1,145 @@
+package userstore
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/pkg/errors"
+)
+
+const queriesFilename = "dynamo-browse-queries.json"
+
+// Store is a per-user store of query history and saved queries.  The data is kept in a JSON file within
+// the user's config directory.
+type Store struct {
+	mutex    *sync.Mutex
+	filename string
+}
+
+type storeData struct {
+	History []string            `json:"history"`
+	Saved   []models.SavedQuery `json:"saved"`
+}
+
+// NewStore returns a new store which will keep its files in dir.  The directory will be created if it doesn't
+// exist.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "cannot create store dir %v", dir)
+	}
+
+	return &Store{
+		mutex:    new(sync.Mutex),
+		filename: filepath.Join(dir, queriesFilename),
+	}, nil
+}
+
+// DefaultDir returns the default directory of the user store.
+func DefaultDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errors.Wrap(err, "cannot determine user config dir")
+	}
+	return filepath.Join(configDir, "audax"), nil
+}
+
+func (s *Store) QueryHistory(ctx context.Context) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return data.History, nil
+}
+
+func (s *Store) SetQueryHistory(ctx context.Context, history []string) error {
+	return s.update(func(data *storeData) {
+		data.History = history
+	})
+}
+
+func (s *Store) SavedQueries(ctx context.Context, tableName string) ([]models.SavedQuery, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	savedQueries := make([]models.SavedQuery, 0)
+	for _, q := range data.Saved {
+		if q.TableName == tableName {
+			savedQueries = append(savedQueries, q)
+		}
+	}
+	return savedQueries, nil
+}
+
+func (s *Store) SaveQuery(ctx context.Context, query models.SavedQuery) error {
+	return s.update(func(data *storeData) {
+		for i, q := range data.Saved {
+			if q.TableName == query.TableName && q.Name == query.Name {
+				data.Saved[i] = query
+				return
+			}
+		}
+		data.Saved = append(data.Saved, query)
+	})
+}
+
+func (s *Store) DeleteQuery(ctx context.Context, tableName, name string) error {
+	return s.update(func(data *storeData) {
+		newSaved := make([]models.SavedQuery, 0, len(data.Saved))
+		for _, q := range data.Saved {
+			if q.TableName != tableName || q.Name != name {
+				newSaved = append(newSaved, q)
+			}
+		}
+		data.Saved = newSaved
+	})
+}
+
+func (s *Store) update(fn func(data *storeData)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	fn(&data)
+
+	bts, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "cannot encode user store")
+	}
+
+	if err := os.WriteFile(s.filename, bts, 0644); err != nil {
+		return errors.Wrapf(err, "cannot write user store %v", s.filename)
+	}
+	return nil
+}
+
+func (s *Store) read() (storeData, error) {
+	var data storeData
+
+	bts, err := os.ReadFile(s.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return data, nil
+	} else if err != nil {
+		return data, errors.Wrapf(err, "cannot read user store %v", s.filename)
+	}
+
+	if err := json.Unmarshal(bts, &data); err != nil {
+		return data, errors.Wrapf(err, "cannot decode user store %v", s.filename)
+	}
+	return data, nil
+}
@@ -0,0 +1,15 @@
+package savedqueries
+
+import (
+	"context"
+
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+)
+
+type QueryStore interface {
+	QueryHistory(ctx context.Context) ([]string, error)
+	SetQueryHistory(ctx context.Context, history []string) error
+	SavedQueries(ctx context.Context, tableName string) ([]models.SavedQuery, error)
+	SaveQuery(ctx context.Context, query models.SavedQuery) error
+	DeleteQuery(ctx context.Context, tableName, name string) error
+}
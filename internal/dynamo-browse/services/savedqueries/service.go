@@ -0,0 +1,84 @@
+package savedqueries
+
+import (
+	"context"
+
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/pkg/errors"
+)
+
+// maxHistory is the maximum number of queries kept in the query history
+const maxHistory = 100
+
+type Service struct {
+	store QueryStore
+}
+
+func NewService(store QueryStore) *Service {
+	return &Service{
+		store: store,
+	}
+}
+
+// History returns the query history, with the oldest query first.
+func (s *Service) History(ctx context.Context) ([]string, error) {
+	return s.store.QueryHistory(ctx)
+}
+
+// AddToHistory adds a query to the end of the history.  If the query is already in the history, it will be
+// moved to the end.
+func (s *Service) AddToHistory(ctx context.Context, query string) error {
+	if query == "" {
+		return nil
+	}
+
+	history, err := s.store.QueryHistory(ctx)
+	if err != nil {
+		return err
+	}
+
+	newHistory := make([]string, 0, len(history)+1)
+	for _, h := range history {
+		if h != query {
+			newHistory = append(newHistory, h)
+		}
+	}
+	newHistory = append(newHistory, query)
+
+	if len(newHistory) > maxHistory {
+		newHistory = newHistory[len(newHistory)-maxHistory:]
+	}
+	return s.store.SetQueryHistory(ctx, newHistory)
+}
+
+func (s *Service) List(ctx context.Context, tableName string) ([]models.SavedQuery, error) {
+	return s.store.SavedQueries(ctx, tableName)
+}
+
+func (s *Service) Lookup(ctx context.Context, tableName, name string) (models.SavedQuery, error) {
+	savedQueries, err := s.store.SavedQueries(ctx, tableName)
+	if err != nil {
+		return models.SavedQuery{}, err
+	}
+
+	for _, q := range savedQueries {
+		if q.Name == name {
+			return q, nil
+		}
+	}
+	return models.SavedQuery{}, errors.Errorf("no saved query with name '%v' for table %v", name, tableName)
+}
+
+func (s *Service) Save(ctx context.Context, query models.SavedQuery) error {
+	if query.Name == "" {
+		return errors.New("saved query must have a name")
+	}
+	return s.store.SaveQuery(ctx, query)
+}
+
+func (s *Service) Delete(ctx context.Context, tableName, name string) error {
+	if _, err := s.Lookup(ctx, tableName, name); err != nil {
+		return err
+	}
+	return s.store.DeleteQuery(ctx, tableName, name)
+}
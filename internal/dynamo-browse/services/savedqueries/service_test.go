@@ -0,0 +1,103 @@
+package savedqueries_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/lmika/audax/internal/dynamo-browse/providers/userstore"
+	"github.com/lmika/audax/internal/dynamo-browse/services/savedqueries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_AddToHistory(t *testing.T) {
+	t.Run("should add queries to the end of the history", func(t *testing.T) {
+		ctx := context.Background()
+		service := newService(t)
+
+		assert.NoError(t, service.AddToHistory(ctx, `pk = "abc"`))
+		assert.NoError(t, service.AddToHistory(ctx, `pk = "def"`))
+
+		history, err := service.History(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`pk = "abc"`, `pk = "def"`}, history)
+	})
+
+	t.Run("should move repeated queries to the end of the history", func(t *testing.T) {
+		ctx := context.Background()
+		service := newService(t)
+
+		assert.NoError(t, service.AddToHistory(ctx, `pk = "abc"`))
+		assert.NoError(t, service.AddToHistory(ctx, `pk = "def"`))
+		assert.NoError(t, service.AddToHistory(ctx, `pk = "abc"`))
+
+		history, err := service.History(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`pk = "def"`, `pk = "abc"`}, history)
+	})
+
+	t.Run("should limit the size of the history", func(t *testing.T) {
+		ctx := context.Background()
+		service := newService(t)
+
+		for i := 0; i < 150; i++ {
+			assert.NoError(t, service.AddToHistory(ctx, fmt.Sprintf(`pk = "%d"`, i)))
+		}
+
+		history, err := service.History(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, history, 100)
+		assert.Equal(t, `pk = "50"`, history[0])
+		assert.Equal(t, `pk = "149"`, history[99])
+	})
+}
+
+func TestService_Save(t *testing.T) {
+	t.Run("should save queries per table", func(t *testing.T) {
+		ctx := context.Background()
+		service := newService(t)
+
+		assert.NoError(t, service.Save(ctx, models.SavedQuery{Name: "abc", TableName: "alpha", Query: `pk = "abc"`}))
+		assert.NoError(t, service.Save(ctx, models.SavedQuery{Name: "abc", TableName: "bravo", Query: `pk = "123"`}))
+
+		q, err := service.Lookup(ctx, "alpha", "abc")
+		assert.NoError(t, err)
+		assert.Equal(t, `pk = "abc"`, q.Query)
+
+		q, err = service.Lookup(ctx, "bravo", "abc")
+		assert.NoError(t, err)
+		assert.Equal(t, `pk = "123"`, q.Query)
+	})
+
+	t.Run("should replace query with the same name", func(t *testing.T) {
+		ctx := context.Background()
+		service := newService(t)
+
+		assert.NoError(t, service.Save(ctx, models.SavedQuery{Name: "abc", TableName: "alpha", Query: `pk = "abc"`}))
+		assert.NoError(t, service.Save(ctx, models.SavedQuery{Name: "abc", TableName: "alpha", Query: `pk = "def"`}))
+
+		qs, err := service.List(ctx, "alpha")
+		assert.NoError(t, err)
+		assert.Len(t, qs, 1)
+		assert.Equal(t, `pk = "def"`, qs[0].Query)
+	})
+
+	t.Run("should delete saved query", func(t *testing.T) {
+		ctx := context.Background()
+		service := newService(t)
+
+		assert.NoError(t, service.Save(ctx, models.SavedQuery{Name: "abc", TableName: "alpha", Query: `pk = "abc"`}))
+		assert.NoError(t, service.Delete(ctx, "alpha", "abc"))
+
+		_, err := service.Lookup(ctx, "alpha", "abc")
+		assert.Error(t, err)
+	})
+}
+
+func newService(t *testing.T) *savedqueries.Service {
+	store, err := userstore.NewStore(t.TempDir())
+	assert.NoError(t, err)
+
+	return savedqueries.NewService(store)
+}
@@ -11,7 +11,12 @@ import (
 type TableProvider interface {
 	ListTables(ctx context.Context) ([]string, error)
 	DescribeTable(ctx context.Context, tableName string) (*models.TableInfo, error)
+	DescribeTableSpec(ctx context.Context, tableName string) (*models.TableSpec, error)
+	CreateTable(ctx context.Context, spec models.TableSpec) error
+	DeleteTable(ctx context.Context, tableName string) error
 	ScanItems(ctx context.Context, tableName string, filterExpr *expression.Expression, maxItems int) ([]models.Item, error)
+	ScanPages(ctx context.Context, tableName string, onPage func(items []models.Item) error) error
+	ExecuteStatement(ctx context.Context, statement string, maxItems int) ([]models.Item, error)
 	DeleteItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) error
 	PutItem(ctx context.Context, name string, item models.Item) error
 	PutItems(ctx context.Context, name string, items []models.Item) error
@@ -10,14 +10,37 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	defaultScanLimit = 1000
+)
+
+// ErrReadOnlyResultSet is returned when attempting to put or delete the items of a read-only result set
+var ErrReadOnlyResultSet = errors.New("result set is read-only as it does not have all attributes: use 'SELECT *' to modify items")
+
 type Service struct {
-	provider TableProvider
+	provider  TableProvider
+	scanLimit int
 }
 
 func NewService(provider TableProvider) *Service {
 	return &Service{
-		provider: provider,
+		provider:  provider,
+		scanLimit: defaultScanLimit,
+	}
+}
+
+// SetScanLimit sets the maximum number of items returned from scans and queries.  A limit of zero or less
+// will reset it to the default.
+func (s *Service) SetScanLimit(limit int) {
+	if limit <= 0 {
+		limit = defaultScanLimit
 	}
+	s.scanLimit = limit
+}
+
+// ScanLimit returns the maximum number of items returned by a scan or query.
+func (s *Service) ScanLimit() int {
+	return s.scanLimit
 }
 
 func (s *Service) ListTables(ctx context.Context) ([]string, error) {
@@ -28,6 +51,44 @@ func (s *Service) Describe(ctx context.Context, table string) (*models.TableInfo
 	return s.provider.DescribeTable(ctx, table)
 }
 
+func (s *Service) CreateTable(ctx context.Context, spec models.TableSpec) error {
+	return s.provider.CreateTable(ctx, spec)
+}
+
+func (s *Service) DeleteTable(ctx context.Context, tableName string) error {
+	return s.provider.DeleteTable(ctx, tableName)
+}
+
+// CloneTable creates a new table with the same schema as the source table.  If copyItems is true, all the items
+// of the source table will also be copied across, a page at a time.  Returns the number of items copied.
+func (s *Service) CloneTable(ctx context.Context, sourceTable, newTableName string, copyItems bool) (int, error) {
+	spec, err := s.provider.DescribeTableSpec(ctx, sourceTable)
+	if err != nil {
+		return 0, err
+	}
+
+	spec.Name = newTableName
+	if err := s.provider.CreateTable(ctx, *spec); err != nil {
+		return 0, err
+	}
+
+	if !copyItems {
+		return 0, nil
+	}
+
+	copied := 0
+	if err := s.provider.ScanPages(ctx, sourceTable, func(items []models.Item) error {
+		if err := s.provider.PutItems(ctx, newTableName, items); err != nil {
+			return errors.Wrapf(err, "unable to copy items to %v after copying %d items", newTableName, copied)
+		}
+		copied += len(items)
+		return nil
+	}); err != nil {
+		return copied, err
+	}
+	return copied, nil
+}
+
 func (s *Service) Scan(ctx context.Context, tableInfo *models.TableInfo) (*models.ResultSet, error) {
 	return s.doScan(ctx, tableInfo, nil)
 }
@@ -35,6 +96,10 @@ func (s *Service) Scan(ctx context.Context, tableInfo *models.TableInfo) (*model
 func (s *Service) doScan(ctx context.Context, tableInfo *models.TableInfo, expr models.Queryable) (*models.ResultSet, error) {
 	var filterExpr *expression.Expression
 
+	if stmt, isStmt := expr.(*models.PartiQLStatement); isStmt {
+		return s.executeSelect(ctx, tableInfo, stmt)
+	}
+
 	if expr != nil {
 		plan, err := expr.Plan(tableInfo)
 		if err != nil {
@@ -49,7 +114,7 @@ func (s *Service) doScan(ctx context.Context, tableInfo *models.TableInfo, expr
 		filterExpr = &plan.Expression
 	}
 
-	results, err := s.provider.ScanItems(ctx, tableInfo.Name, filterExpr, 1000)
+	results, err := s.provider.ScanItems(ctx, tableInfo.Name, filterExpr, s.scanLimit)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to scan table %v", tableInfo.Name)
 	}
@@ -100,11 +165,71 @@ func (s *Service) doScan(ctx context.Context, tableInfo *models.TableInfo, expr
 	return resultSet, nil
 }
 
+// ExecuteStatement executes a PartiQL statement.  If the statement is a SELECT, the selected items are returned
+// as a result set.  Otherwise, the result set will be nil and the number of affected items will be returned.  The
+// number of affected items is only known if the statement has a RETURNING clause, and will be -1 if it has not.
+func (s *Service) ExecuteStatement(ctx context.Context, stmt *models.PartiQLStatement) (*models.ResultSet, int, error) {
+	if !stmt.IsSelect() {
+		items, err := s.provider.ExecuteStatement(ctx, stmt.Statement, s.scanLimit)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// Statements that modify items only return the items they modify when RETURNING is used
+		if !stmt.HasReturning() {
+			return nil, -1, nil
+		}
+		return nil, len(items), nil
+	}
+
+	tableName, err := stmt.TableName()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tableInfo, err := s.provider.DescribeTable(ctx, tableName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resultSet, err := s.executeSelect(ctx, tableInfo, stmt)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resultSet, len(resultSet.Items()), nil
+}
+
+func (s *Service) executeSelect(ctx context.Context, tableInfo *models.TableInfo, stmt *models.PartiQLStatement) (*models.ResultSet, error) {
+	results, err := s.provider.ExecuteStatement(ctx, stmt.Statement, s.scanLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	resultSet := &models.ResultSet{
+		TableInfo: tableInfo,
+		Query:     stmt,
+		ReadOnly:  !stmt.SelectsAllAttributes(),
+	}
+	resultSet.SetItems(results)
+	resultSet.RefreshColumns()
+
+	return resultSet, nil
+}
+
 func (s *Service) Put(ctx context.Context, tableInfo *models.TableInfo, item models.Item) error {
 	return s.provider.PutItem(ctx, tableInfo.Name, item)
 }
 
+// PutItems puts the items in the table, in batches.
+func (s *Service) PutItems(ctx context.Context, tableInfo *models.TableInfo, items []models.Item) error {
+	return s.provider.PutItems(ctx, tableInfo.Name, items)
+}
+
 func (s *Service) PutItemAt(ctx context.Context, resultSet *models.ResultSet, index int) error {
+	if resultSet.ReadOnly {
+		return ErrReadOnlyResultSet
+	}
+
 	item := resultSet.Items()[index]
 	if err := s.provider.PutItem(ctx, resultSet.TableInfo.Name, item); err != nil {
 		return err
@@ -118,6 +243,8 @@ func (s *Service) PutItemAt(ctx context.Context, resultSet *models.ResultSet, in
 func (s *Service) PutSelectedItems(ctx context.Context, resultSet *models.ResultSet, markedItems []models.ItemIndex) error {
 	if len(markedItems) == 0 {
 		return nil
+	} else if resultSet.ReadOnly {
+		return ErrReadOnlyResultSet
 	}
 
 	if err := s.provider.PutItems(ctx, resultSet.TableInfo.Name, sliceutils.Map(markedItems, func(t models.ItemIndex) models.Item {
@@ -53,6 +53,36 @@ func TestService_Scan(t *testing.T) {
 	})
 }
 
+func TestService_CloneTable(t *testing.T) {
+	tableName := "service-test-data"
+
+	client := testdynamo.SetupTestTable(t, testData)
+	provider := dynamo.NewProvider(client)
+
+	t.Run("should create table with same schema and items", func(t *testing.T) {
+		ctx := context.Background()
+		newTableName := "service-test-data-clone"
+
+		service := tables.NewService(provider)
+		n, err := service.CloneTable(ctx, tableName, newTableName, true)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+
+		t.Cleanup(func() {
+			service.DeleteTable(ctx, newTableName)
+		})
+
+		ti, err := service.Describe(ctx, newTableName)
+		assert.NoError(t, err)
+		assert.Equal(t, "pk", ti.Keys.PartitionKey)
+		assert.Equal(t, "sk", ti.Keys.SortKey)
+
+		rs, err := service.Scan(ctx, ti)
+		assert.NoError(t, err)
+		assert.Len(t, rs.Items(), 3)
+	})
+}
+
 var testData = []testdynamo.TestData{
 	{
 		TableName: "service-test-data",
@@ -0,0 +1,97 @@
+package keybindings
+
+import "github.com/charmbracelet/bubbles/key"
+
+// KeyBindings are the key bindings used by dynamo-browse.  Each binding can be rebound from the user
+// config file using the name within the "config" tags, such as "table.move-up".
+type KeyBindings struct {
+	Table    *TableKeyBinding    `config:"table"`
+	ItemView *ItemViewKeyBinding `config:"item-view"`
+	View     *ViewKeyBindings    `config:"view"`
+}
+
+type TableKeyBinding struct {
+	MoveUp       key.Binding `config:"move-up"`
+	MoveDown     key.Binding `config:"move-down"`
+	PageUp       key.Binding `config:"page-up"`
+	PageDown     key.Binding `config:"page-down"`
+	Home         key.Binding `config:"goto-top"`
+	End          key.Binding `config:"goto-bottom"`
+	ColLeft      key.Binding `config:"move-left"`
+	ColRight     key.Binding `config:"move-right"`
+	WidenColumn  key.Binding `config:"widen-column"`
+	NarrowColumn key.Binding `config:"narrow-column"`
+	ShowCell     key.Binding `config:"show-cell"`
+	NextMatch    key.Binding `config:"next-match"`
+	PrevMatch    key.Binding `config:"prev-match"`
+}
+
+type ItemViewKeyBinding struct {
+	MoveUp    key.Binding `config:"move-up"`
+	MoveDown  key.Binding `config:"move-down"`
+	Expand    key.Binding `config:"expand"`
+	Collapse  key.Binding `config:"collapse"`
+	Toggle    key.Binding `config:"toggle"`
+	CopyValue key.Binding `config:"copy-value"`
+	CopyPath  key.Binding `config:"copy-path"`
+}
+
+type ViewKeyBindings struct {
+	Mark             key.Binding `config:"mark"`
+	Rescan           key.Binding `config:"rescan"`
+	PromptForQuery   key.Binding `config:"prompt-for-query"`
+	PromptForFilter  key.Binding `config:"prompt-for-filter"`
+	PromptForSearch  key.Binding `config:"prompt-for-search"`
+	PromptForCommand key.Binding `config:"prompt-for-command"`
+	YankCell         key.Binding `config:"yank-cell"`
+	YankItem         key.Binding `config:"yank-item"`
+	ToggleFocus      key.Binding `config:"toggle-focus"`
+	GrowItemView     key.Binding `config:"grow-item-view"`
+	ShrinkItemView   key.Binding `config:"shrink-item-view"`
+	MaximiseItemView key.Binding `config:"maximise-item-view"`
+	Quit             key.Binding `config:"quit"`
+}
+
+func Default() *KeyBindings {
+	return &KeyBindings{
+		Table: &TableKeyBinding{
+			MoveUp:       key.NewBinding(key.WithKeys("i", "up"), key.WithHelp("↑/i", "up")),
+			MoveDown:     key.NewBinding(key.WithKeys("k", "down"), key.WithHelp("↓/k", "down")),
+			PageUp:       key.NewBinding(key.WithKeys("I", "pgup"), key.WithHelp("pgup/I", "prev page")),
+			PageDown:     key.NewBinding(key.WithKeys("K", "pgdown"), key.WithHelp("pgdn/K", "next page")),
+			Home:         key.NewBinding(key.WithKeys("0", "home")),
+			End:          key.NewBinding(key.WithKeys("$", "end")),
+			ColLeft:      key.NewBinding(key.WithKeys("j", "left")),
+			ColRight:     key.NewBinding(key.WithKeys("l", "right")),
+			WidenColumn:  key.NewBinding(key.WithKeys(">"), key.WithHelp(">", "widen column")),
+			NarrowColumn: key.NewBinding(key.WithKeys("<"), key.WithHelp("<", "narrow column")),
+			ShowCell:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "show cell value")),
+			NextMatch:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next search match")),
+			PrevMatch:    key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "previous search match")),
+		},
+		ItemView: &ItemViewKeyBinding{
+			MoveUp:    key.NewBinding(key.WithKeys("i", "up"), key.WithHelp("↑/i", "up")),
+			MoveDown:  key.NewBinding(key.WithKeys("k", "down"), key.WithHelp("↓/k", "down")),
+			Expand:    key.NewBinding(key.WithKeys("l", "right"), key.WithHelp("→/l", "expand")),
+			Collapse:  key.NewBinding(key.WithKeys("j", "left"), key.WithHelp("←/j", "collapse")),
+			Toggle:    key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "expand/collapse")),
+			CopyValue: key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy value")),
+			CopyPath:  key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", "copy path")),
+		},
+		View: &ViewKeyBindings{
+			Mark:             key.NewBinding(key.WithKeys("m")),
+			Rescan:           key.NewBinding(key.WithKeys("R")),
+			PromptForQuery:   key.NewBinding(key.WithKeys("?")),
+			PromptForFilter:  key.NewBinding(key.WithKeys("/")),
+			PromptForSearch:  key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "search")),
+			PromptForCommand: key.NewBinding(key.WithKeys(":")),
+			YankCell:         key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy selected cell")),
+			YankItem:         key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", "copy selected item as JSON")),
+			ToggleFocus:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "switch table/item focus")),
+			GrowItemView:     key.NewBinding(key.WithKeys("+"), key.WithHelp("+", "grow item view")),
+			ShrinkItemView:   key.NewBinding(key.WithKeys("-"), key.WithHelp("-", "shrink item view")),
+			MaximiseItemView: key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "maximise item view")),
+			Quit:             key.NewBinding(key.WithKeys("ctrl+c", "esc")),
+		},
+	}
+}
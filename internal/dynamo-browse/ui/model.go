@@ -1,15 +1,20 @@
 package ui
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lmika/audax/internal/common/ui/commandctrl"
 	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/common/userconfig"
 	"github.com/lmika/audax/internal/dynamo-browse/controllers"
 	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/lmika/audax/internal/dynamo-browse/models/itemrender"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/keybindings"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/dialogprompt"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/dynamoitemedit"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/dynamoitemview"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/dynamotableview"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/helpview"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/layout"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/statusandprompt"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/styles"
@@ -21,27 +26,48 @@ import (
 type Model struct {
 	tableReadController  *controllers.TableReadController
 	tableWriteController *controllers.TableWriteController
+	tableAdminController *controllers.TableAdminController
+	connectionController *controllers.ConnectionController
+	savedQueryController *controllers.SavedQueryController
 	commandController    *commandctrl.CommandController
 	itemEdit             *dynamoitemedit.Model
 	statusAndPrompt      *statusandprompt.StatusAndPrompt
 	tableSelect          *tableselect.Model
+	helpView             *helpview.Model
+	keyBindings          *keybindings.KeyBindings
+	itemViewSize         *layout.AdjustableLastChild
 
 	root      tea.Model
 	tableView *dynamotableview.Model
 	itemView  *dynamoitemview.Model
 }
 
-func NewModel(rc *controllers.TableReadController, wc *controllers.TableWriteController, cc *commandctrl.CommandController) Model {
-	uiStyles := styles.DefaultStyles
-
-	dtv := dynamotableview.New(uiStyles)
-	div := dynamoitemview.New(uiStyles)
-	mainView := layout.NewVBox(layout.LastChildFixedAt(13), dtv, div)
+func NewModel(rc *controllers.TableReadController, wc *controllers.TableWriteController, ac *controllers.TableAdminController, cnc *controllers.ConnectionController, sqc *controllers.SavedQueryController, sc *controllers.ScriptController, cc *commandctrl.CommandController, keyBindings *keybindings.KeyBindings, uiStyles styles.Styles) Model {
+	dtv := dynamotableview.New(keyBindings.Table, uiStyles)
+	div := dynamoitemview.New(keyBindings.ItemView, uiStyles)
+	itemViewSize := layout.NewAdjustableLastChild(13, 4)
+	mainView := layout.NewVBox(itemViewSize, dtv, div)
 
 	itemEdit := dynamoitemedit.NewModel(mainView)
-	statusAndPrompt := statusandprompt.New(itemEdit, "", uiStyles.StatusAndPrompt)
-	dialogPrompt := dialogprompt.New(statusAndPrompt)
-	tableSelect := tableselect.New(dialogPrompt, uiStyles)
+	dialogPrompt := dialogprompt.New(itemEdit)
+	tableSelect := tableselect.New(dialogPrompt, keyBindings.Table, uiStyles)
+	helpView := helpview.New(tableSelect, userconfig.DescribeKeyBindings(keyBindings), uiStyles.Frames)
+	statusAndPrompt := statusandprompt.New(helpView, "", uiStyles.StatusAndPrompt)
+
+	tableArg := commandctrl.ArgSpec{Name: "table", Completer: commandctrl.CompleteFrom(rc.TableNames)}
+	attrArg := commandctrl.ArgSpec{Name: "attribute", Completer: commandctrl.CompleteFrom(rc.AttributeNames)}
+	queryArg := commandctrl.ArgSpec{Name: "query", Optional: true, Completer: commandctrl.CompleteFrom(rc.AttributeNames)}
+	savedQueryArg := commandctrl.ArgSpec{Name: "name", Completer: commandctrl.CompleteFrom(sqc.SavedQueryNames)}
+	fileArg := commandctrl.ArgSpec{Name: "filename", Completer: commandctrl.CompleteFiles}
+
+	setAttrHelp := commandctrl.CommandHelp{
+		Description: "set an attribute of the selected item",
+		Args:        []commandctrl.ArgSpec{{Name: "-S|-N|-BOOL|-NULL", Optional: true}, attrArg},
+	}
+	delAttrHelp := commandctrl.CommandHelp{
+		Description: "delete an attribute of the selected item",
+		Args:        []commandctrl.ArgSpec{attrArg},
+	}
 
 	cc.AddCommands(&commandctrl.CommandContext{
 		Commands: map[string]commandctrl.Command{
@@ -59,8 +85,106 @@ func NewModel(rc *controllers.TableReadController, wc *controllers.TableWriteCon
 				}
 				return rc.ExportCSV(args[0])
 			},
-			"unmark": commandctrl.NoArgCommand(rc.Unmark()),
-			"delete": commandctrl.NoArgCommand(wc.DeleteMarked()),
+			"mk-table": func(args []string) tea.Cmd {
+				spec, err := parseTableSpecArgs(args)
+				if err != nil {
+					return events.SetError(err)
+				}
+				return ac.CreateTable(spec)
+			},
+			"rm-table": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return ac.DeleteTable("")
+				}
+				return ac.DeleteTable(args[0])
+			},
+			"clone-table": func(args []string) tea.Cmd {
+				var copyItems bool
+				if len(args) > 0 && args[0] == "-data" {
+					copyItems = true
+					args = args[1:]
+				}
+
+				switch len(args) {
+				case 1:
+					return ac.CloneTable("", args[0], copyItems)
+				case 2:
+					return ac.CloneTable(args[0], args[1], copyItems)
+				}
+				return events.SetError(errors.New("usage: clone-table [-data] [source] <new-name>"))
+			},
+			"profile": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return events.SetStatus("profile: " + cnc.Options().Profile)
+				}
+				return cnc.SwitchProfile(args[0])
+			},
+			"region": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return events.SetStatus("region: " + cnc.Options().Region)
+				}
+				return cnc.SwitchRegion(args[0])
+			},
+			"sql": commandctrl.NoArgCommand(rc.PromptForStatement()),
+			"save-query": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return events.SetError(errors.New("expected name"))
+				}
+				return sqc.SaveQuery(args[0])
+			},
+			"run": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return sqc.ListSavedQueries()
+				}
+				return sqc.RunSavedQuery(args[0])
+			},
+			"rm-query": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return events.SetError(errors.New("expected name"))
+				}
+				return sqc.DeleteSavedQuery(args[0])
+			},
+			"query": func(args []string) tea.Cmd {
+				return rc.RunQuery(strings.Join(args, " "))
+			},
+			"filter": func(args []string) tea.Cmd {
+				return rc.SetFilter(strings.Join(args, " "))
+			},
+			"search": func(args []string) tea.Cmd {
+				return dtv.Search(strings.Join(args, " "))
+			},
+			"yank": func(args []string) tea.Cmd {
+				switch {
+				case len(args) == 0:
+					return rc.YankItem(dtv.SelectedItemIndex())
+				case args[0] == "-marked":
+					return rc.YankMarked()
+				case args[0] == "-cell":
+					return rc.YankAttribute(dtv.SelectedItemIndex(), dtv.SelectedColumn())
+				}
+				return rc.YankAttribute(dtv.SelectedItemIndex(), args[0])
+			},
+			"decode": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return events.SetError(errors.New("expected attribute"))
+				}
+				decoders, err := itemrender.ParseDecoders(args[1:])
+				if err != nil {
+					return events.SetError(err)
+				}
+				return func() tea.Msg {
+					return dynamoitemview.SetDecoders{Path: args[0], Decoders: decoders}
+				}
+			},
+			"load-script": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return events.SetError(errors.New("expected filename"))
+				}
+				return sc.LoadScriptCmd(args[0])
+			},
+			"queries": commandctrl.NoArgCommand(sqc.ListSavedQueries()),
+			"unmark":  commandctrl.NoArgCommand(rc.Unmark()),
+			"delete":  commandctrl.NoArgCommand(wc.DeleteMarked()),
 
 			// TEMP
 			"new-item": commandctrl.NoArgCommand(wc.NewItem()),
@@ -111,17 +235,115 @@ func NewModel(rc *controllers.TableReadController, wc *controllers.TableWriteCon
 			"w":  cc.Alias("put"),
 			"q":  cc.Alias("quit"),
 		},
+		Help: map[string]commandctrl.CommandHelp{
+			"quit": {Description: "quit"},
+			"table": {
+				Description: "scan a table, or select from a list of tables",
+				Args:        []commandctrl.ArgSpec{optionalArg(tableArg)},
+			},
+			"export": {
+				Description: "export the current result set as CSV",
+				Args:        []commandctrl.ArgSpec{fileArg},
+			},
+			"mk-table": {
+				Description: "create a table",
+				Args: []commandctrl.ArgSpec{
+					{Name: "name"},
+					{Name: "pk[:type]"},
+					{Name: "sk[:type]", Optional: true},
+					{Name: "-on-demand|-rcu N|-wcu N|-gsi name=pk:type[,sk:type]", Optional: true},
+				},
+			},
+			"rm-table": {
+				Description: "delete a table",
+				Args:        []commandctrl.ArgSpec{optionalArg(tableArg)},
+			},
+			"clone-table": {
+				Description: "create a copy of a table, optionally with its items",
+				Args:        []commandctrl.ArgSpec{{Name: "-data", Optional: true}, optionalArg(tableArg), {Name: "new-name"}},
+			},
+			"profile": {
+				Description: "show or switch the AWS profile",
+				Args:        []commandctrl.ArgSpec{{Name: "profile", Optional: true}},
+			},
+			"region": {
+				Description: "show or switch the AWS region",
+				Args:        []commandctrl.ArgSpec{{Name: "region", Optional: true}},
+			},
+			"sql": {Description: "run a PartiQL statement"},
+			"save-query": {
+				Description: "save the current query",
+				Args:        []commandctrl.ArgSpec{{Name: "name"}},
+			},
+			"run": {
+				Description: "run a saved query, or list saved queries",
+				Args:        []commandctrl.ArgSpec{optionalArg(savedQueryArg)},
+			},
+			"rm-query": {
+				Description: "delete a saved query",
+				Args:        []commandctrl.ArgSpec{savedQueryArg},
+			},
+			"query": {
+				Description: "run a query against the current table, or scan the table",
+				Args:        []commandctrl.ArgSpec{queryArg},
+			},
+			"filter": {
+				Description: "filter the current result set, or clear the filter",
+				Args:        []commandctrl.ArgSpec{{Name: "filter", Optional: true}},
+			},
+			"search": {
+				Description: "highlight and move to cells matching text, /regexp/ or column:text, or clear the search",
+				Args:        []commandctrl.ArgSpec{{Name: "search", Optional: true, Completer: attrArg.Completer}},
+			},
+			"yank": {
+				Description: "copy the selected item as JSON, an attribute of the selected item, the selected cell or the marked items",
+				Args:        []commandctrl.ArgSpec{{Name: "-marked|-cell|attribute", Optional: true, Completer: attrArg.Completer}},
+			},
+			"decode": {
+				Description: "decode a binary or string attribute in the item view, or display it as is",
+				Args: []commandctrl.ArgSpec{
+					attrArg,
+					{Name: "decoder", Optional: true, Completer: commandctrl.CompleteFrom(itemrender.DecoderNames)},
+				},
+			},
+			"load-script": {
+				Description: "load a Lua script defining new commands",
+				Args:        []commandctrl.ArgSpec{fileArg},
+			},
+			"queries":     {Description: "list saved queries of the current table"},
+			"unmark":      {Description: "unmark all items"},
+			"delete":      {Description: "delete marked items"},
+			"new-item":    {Description: "add a new item"},
+			"set-attr":    setAttrHelp,
+			"del-attr":    delAttrHelp,
+			"put":         {Description: "write modified items to the table"},
+			"touch":       {Description: "write the selected item to the table, unmodified"},
+			"noisy-touch": {Description: "delete and rewrite the selected item"},
+			"sa":          setAttrHelp,
+			"da":          delAttrHelp,
+			"w":           {Description: "alias of put"},
+			"q":           {Description: "alias of quit"},
+		},
 	})
 
-	root := layout.FullScreen(tableSelect)
+	sc.SetSelectedItemIndexFunc(dtv.SelectedItemIndex)
+	cc.AddCommands(sc.CommandContext())
+
+	root := layout.FullScreen(statusAndPrompt)
 
 	return Model{
 		tableReadController:  rc,
 		tableWriteController: wc,
+		tableAdminController: ac,
+		connectionController: cnc,
+		savedQueryController: sqc,
 		commandController:    cc,
 		itemEdit:             itemEdit,
 		statusAndPrompt:      statusAndPrompt,
 		tableSelect:          tableSelect,
+		helpView:             helpView,
+		keyBindings:          keyBindings,
+		itemViewSize:         itemViewSize,
 		root:                 root,
 		tableView:            dtv,
 		itemView:             div,
@@ -129,7 +351,10 @@ func NewModel(rc *controllers.TableReadController, wc *controllers.TableWriteCon
 }
 
 func (m Model) Init() tea.Cmd {
-	return m.tableReadController.Init()
+	return tea.Batch(
+		m.connectionController.Init(),
+		m.commandController.Sequence(m.tableReadController.Init(), m.commandController.Init()),
+	)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -137,24 +362,54 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case controllers.ResultSetUpdated:
 		return m, m.tableView.Refresh()
 	case tea.KeyMsg:
-		if !m.statusAndPrompt.InPrompt() && !m.tableSelect.Visible() {
-			switch msg.String() {
-			case "m":
+		if m.statusAndPrompt.InPrompt() || m.helpView.Visible() || m.tableView.PopupVisible() {
+			break
+		}
+
+		if m.tableSelect.Visible() && !m.tableSelect.Filtering() {
+			switch {
+			case key.Matches(msg, m.keyBindings.View.PromptForCommand):
+				return m, m.commandController.Prompt()
+			}
+		} else if !m.tableSelect.Visible() {
+			switch {
+			case key.Matches(msg, m.keyBindings.View.Mark):
 				if idx := m.tableView.SelectedItemIndex(); idx >= 0 {
 					return m, m.tableWriteController.ToggleMark(idx)
 				}
-			case "R":
+			case key.Matches(msg, m.keyBindings.View.Rescan):
 				return m, m.tableReadController.Rescan()
-			case "?":
-				return m, m.tableReadController.PromptForQuery()
-			case "/":
+			case key.Matches(msg, m.keyBindings.View.PromptForQuery):
+				return m, m.savedQueryController.PromptForQuery()
+			case key.Matches(msg, m.keyBindings.View.PromptForFilter):
 				return m, m.tableReadController.Filter()
+			case key.Matches(msg, m.keyBindings.View.PromptForSearch):
+				return m, m.tableView.PromptForSearch()
 			//case "e":
 			//	m.itemEdit.Visible()
 			//	return m, nil
-			case ":":
+			case key.Matches(msg, m.keyBindings.View.PromptForCommand):
 				return m, m.commandController.Prompt()
-			case "ctrl+c", "esc":
+			case key.Matches(msg, m.keyBindings.View.YankCell) && m.tableView.Focused():
+				return m, m.tableReadController.YankAttribute(m.tableView.SelectedItemIndex(), m.tableView.SelectedColumn())
+			case key.Matches(msg, m.keyBindings.View.YankItem) && m.tableView.Focused():
+				return m, m.tableReadController.YankItem(m.tableView.SelectedItemIndex())
+			case key.Matches(msg, m.keyBindings.View.ToggleFocus):
+				m.setTableFocused(!m.tableView.Focused())
+				return m, nil
+			case key.Matches(msg, m.keyBindings.View.GrowItemView):
+				m.itemViewSize.Adjust(1)
+				return m, layout.Relayout
+			case key.Matches(msg, m.keyBindings.View.ShrinkItemView):
+				m.itemViewSize.Adjust(-1)
+				return m, layout.Relayout
+			case key.Matches(msg, m.keyBindings.View.MaximiseItemView):
+				m.itemViewSize.ToggleMaximised()
+				if m.itemViewSize.Maximised() {
+					m.setTableFocused(false)
+				}
+				return m, layout.Relayout
+			case key.Matches(msg, m.keyBindings.View.Quit):
 				return m, tea.Quit
 			}
 		}
@@ -165,6 +420,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// setTableFocused moves focus between the table view and item view.
+func (m Model) setTableFocused(focused bool) {
+	m.tableView.SetFocused(focused)
+	m.itemView.SetFocused(!focused)
+}
+
+func optionalArg(arg commandctrl.ArgSpec) commandctrl.ArgSpec {
+	arg.Optional = true
+	return arg
+}
+
 func (m Model) View() string {
 	return m.root.View()
 }
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/pkg/errors"
+)
+
+// parseTableSpecArgs parses the arguments of the mk-table command, which are of the form:
+//
+//	<name> <pk>[:type] [<sk>[:type]] [-on-demand] [-rcu N] [-wcu N] [-gsi name=pk:type[,sk:type]]...
+func parseTableSpecArgs(args []string) (models.TableSpec, error) {
+	var spec models.TableSpec
+	var positional []string
+
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			positional = append(positional, args[i])
+			continue
+		}
+
+		switch strings.ToLower(args[i]) {
+		case "-on-demand":
+			spec.OnDemand = true
+			continue
+		}
+
+		if i+1 >= len(args) {
+			return models.TableSpec{}, errors.Errorf("expected value for %v", args[i])
+		}
+		flagName, flagValue := strings.ToLower(args[i]), args[i+1]
+		i++
+
+		switch flagName {
+		case "-rcu", "-wcu":
+			units, err := strconv.ParseInt(flagValue, 10, 64)
+			if err != nil {
+				return models.TableSpec{}, errors.Wrapf(err, "invalid value for %v", flagName)
+			}
+			if flagName == "-rcu" {
+				spec.ReadUnits = units
+			} else {
+				spec.WriteUnits = units
+			}
+		case "-gsi":
+			gsi, err := models.ParseIndexSpec(flagValue)
+			if err != nil {
+				return models.TableSpec{}, err
+			}
+			spec.GSIs = append(spec.GSIs, gsi)
+		default:
+			return models.TableSpec{}, errors.Errorf("unrecognised option: %v", flagName)
+		}
+	}
+
+	if len(positional) < 2 || len(positional) > 3 {
+		return models.TableSpec{}, errors.New("usage: mk-table <name> <pk>[:type] [<sk>[:type]]")
+	}
+
+	spec.Name = positional[0]
+
+	pk, err := models.ParseKeyAttributeSpec(positional[1])
+	if err != nil {
+		return models.TableSpec{}, err
+	}
+	spec.Keys.PartitionKey = pk
+
+	if len(positional) == 3 {
+		sk, err := models.ParseKeyAttributeSpec(positional[2])
+		if err != nil {
+			return models.TableSpec{}, err
+		}
+		spec.Keys.SortKey = &sk
+	}
+
+	return spec, nil
+}
@@ -1,8 +1,18 @@
 package dynamoitemview
 
-import "github.com/lmika/audax/internal/dynamo-browse/models"
+import (
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/lmika/audax/internal/dynamo-browse/models/itemrender"
+)
 
 type NewItemSelected struct {
 	ResultSet *models.ResultSet
 	Item      models.Item
 }
+
+// SetDecoders sets the decoders used to display the attribute at the path.  Nested attributes are addressed by
+// joining the keys with dots.  Setting no decoders will display the attribute as is.
+type SetDecoders struct {
+	Path     string
+	Decoders []itemrender.Decoder
+}
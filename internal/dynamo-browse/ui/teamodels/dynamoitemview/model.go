@@ -1,31 +1,30 @@
 package dynamoitemview
 
 import (
-	"fmt"
-	"github.com/lmika/audax/internal/dynamo-browse/models/itemrender"
-	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/styles"
-	"io"
 	"strings"
-	"text/tabwriter"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lmika/audax/internal/common/clipboard"
+	"github.com/lmika/audax/internal/common/ui/events"
 	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/lmika/audax/internal/dynamo-browse/models/itemrender"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/keybindings"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/frame"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/layout"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/styles"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/utils"
 )
 
 var (
-	activeHeaderStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("#ffffff")).
-				Background(lipgloss.Color("#4479ff"))
-
 	fieldTypeStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.AdaptiveColor{Light: "#2B800C", Dark: "#73C653"})
 	metaInfoStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#888888"))
+	selectedRowStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("170"))
 )
 
 type Model struct {
@@ -33,16 +32,34 @@ type Model struct {
 	frameTitle frame.FrameTitle
 	viewport   viewport.Model
 	w, h       int
+	keyBinding *keybindings.ItemViewKeyBinding
+	focused    bool
 
 	// model state
 	currentResultSet *models.ResultSet
 	selectedItem     models.Item
+	decoders         map[string][]itemrender.Decoder
+	collapsed        map[string]bool
+	rows             []itemRow
+	cursor           int
 }
 
-func New(uiStyles styles.Styles) *Model {
+// itemRow is a displayed row of the item tree
+type itemRow struct {
+	depth    int
+	path     string
+	name     string
+	renderer itemrender.Renderer
+	subitems []itemrender.SubItem
+}
+
+func New(keyBinding *keybindings.ItemViewKeyBinding, uiStyles styles.Styles) *Model {
 	return &Model{
 		frameTitle: frame.NewFrameTitle("Item", false, uiStyles.Frames),
 		viewport:   viewport.New(100, 100),
+		keyBinding: keyBinding,
+		decoders:   make(map[string][]itemrender.Decoder),
+		collapsed:  make(map[string]bool),
 	}
 }
 
@@ -55,12 +72,56 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case NewItemSelected:
 		m.currentResultSet = msg.ResultSet
 		m.selectedItem = msg.Item
+		m.cursor = 0
+		m.viewport.SetYOffset(0)
+		m.updateViewportToSelectedMessage()
+		return m, nil
+	case SetDecoders:
+		if len(msg.Decoders) == 0 {
+			delete(m.decoders, msg.Path)
+		} else {
+			m.decoders[msg.Path] = msg.Decoders
+		}
 		m.updateViewportToSelectedMessage()
 		return m, nil
+	case tea.KeyMsg:
+		if !m.focused {
+			break
+		}
+
+		switch {
+		case key.Matches(msg, m.keyBinding.MoveUp):
+			m.moveCursor(m.cursor - 1)
+		case key.Matches(msg, m.keyBinding.MoveDown):
+			m.moveCursor(m.cursor + 1)
+		case key.Matches(msg, m.keyBinding.Expand):
+			m.setCollapsed(false)
+		case key.Matches(msg, m.keyBinding.Collapse):
+			m.setCollapsed(true)
+		case key.Matches(msg, m.keyBinding.Toggle):
+			if row, ok := m.selectedRow(); ok {
+				m.setCollapsed(!m.collapsed[row.path])
+			}
+		case key.Matches(msg, m.keyBinding.CopyValue):
+			if row, ok := m.selectedRow(); ok {
+				return m, copyToClipboard("value", rowValue(row.renderer))
+			}
+		case key.Matches(msg, m.keyBinding.CopyPath):
+			if row, ok := m.selectedRow(); ok {
+				return m, copyToClipboard("path", row.path)
+			}
+		}
 	}
 	return m, nil
 }
 
+// SetFocused sets whether the item view has focus.  The item view only responds to keys while focused.
+func (m *Model) SetFocused(focused bool) {
+	m.focused = focused
+	m.frameTitle.SetActive(focused)
+	m.updateViewportToSelectedMessage()
+}
+
 func (m *Model) View() string {
 	if !m.ready {
 		return ""
@@ -77,47 +138,163 @@ func (m *Model) Resize(w, h int) layout.ResizingModel {
 	} else {
 		m.viewport.Width = w
 		m.viewport.Height = h - m.frameTitle.HeaderHeight()
+		m.scrollToCursor()
 	}
 	m.frameTitle.Resize(w, h)
 	return m
 }
 
+func (m *Model) selectedRow() (itemRow, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return itemRow{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+func (m *Model) moveCursor(newCursor int) {
+	if newCursor < 0 || newCursor >= len(m.rows) {
+		return
+	}
+	m.cursor = newCursor
+	m.updateViewport()
+}
+
+// setCollapsed expands or collapses the selected row.  Collapsing a row which cannot be collapsed will move the
+// cursor to the parent row.
+func (m *Model) setCollapsed(collapsed bool) {
+	row, ok := m.selectedRow()
+	if !ok {
+		return
+	}
+
+	if len(row.subitems) == 0 || (collapsed && m.collapsed[row.path]) {
+		if collapsed {
+			for i := m.cursor - 1; i >= 0; i-- {
+				if m.rows[i].depth < row.depth {
+					m.moveCursor(i)
+					break
+				}
+			}
+		}
+		return
+	}
+
+	if collapsed {
+		m.collapsed[row.path] = true
+	} else {
+		delete(m.collapsed, row.path)
+	}
+	m.updateViewportToSelectedMessage()
+}
+
 func (m *Model) updateViewportToSelectedMessage() {
+	m.rows = m.rows[:0]
 	if m.selectedItem == nil {
+		m.cursor = 0
 		m.viewport.SetContent("")
 		return
 	}
 
-	viewportContent := &strings.Builder{}
-	tabWriter := tabwriter.NewWriter(viewportContent, 0, 1, 1, ' ', 0)
-
 	seenColumns := make(map[string]struct{})
 	for _, colName := range m.currentResultSet.Columns() {
 		seenColumns[colName] = struct{}{}
 		if r := m.selectedItem.Renderer(colName); r != nil {
-			m.renderItem(tabWriter, "", colName, r)
+			m.addRows(0, colName, colName, r)
 		}
 	}
 	for k, _ := range m.selectedItem {
 		if _, seen := seenColumns[k]; !seen {
 			if r := m.selectedItem.Renderer(k); r != nil {
-				m.renderItem(tabWriter, "", k, r)
+				m.addRows(0, k, k, r)
 			}
 		}
 	}
 
-	tabWriter.Flush()
+	if m.cursor >= len(m.rows) {
+		m.cursor = utils.Max(len(m.rows)-1, 0)
+	}
+	m.updateViewport()
+}
+
+func (m *Model) addRows(depth int, path string, name string, r itemrender.Renderer) {
+	if decoders, hasDecoders := m.decoders[path]; hasDecoders {
+		r = itemrender.Decode(r, decoders)
+	}
+
+	subitems := r.SubItems()
+	m.rows = append(m.rows, itemRow{depth: depth, path: path, name: name, renderer: r, subitems: subitems})
+	if m.collapsed[path] {
+		return
+	}
+
+	for _, si := range subitems {
+		m.addRows(depth+1, path+"."+si.Key, si.Key, si.Value)
+	}
+}
+
+func (m *Model) updateViewport() {
+	var nameWidth, typeWidth int
+	for _, row := range m.rows {
+		nameWidth = utils.Max(nameWidth, lipgloss.Width(m.rowName(row)))
+		typeWidth = utils.Max(typeWidth, lipgloss.Width(row.renderer.TypeName()))
+	}
+
+	viewportContent := &strings.Builder{}
+	for i, row := range m.rows {
+		name := m.rowName(row)
+		name += strings.Repeat(" ", nameWidth-lipgloss.Width(name)+1)
+		typeName := row.renderer.TypeName()
+		typeName += strings.Repeat(" ", typeWidth-lipgloss.Width(typeName)+1)
+
+		// Values with sub-items, like JSON strings, are summarised by the meta info
+		value := row.renderer.StringValue()
+		if len(row.subitems) > 0 {
+			value = ""
+		}
+
+		if m.focused && i == m.cursor {
+			viewportContent.WriteString(selectedRowStyle.Render(name + typeName + value + row.renderer.MetaInfo()))
+		} else {
+			viewportContent.WriteString(name + fieldTypeStyle.Render(typeName) + value + metaInfoStyle.Render(row.renderer.MetaInfo()))
+		}
+		viewportContent.WriteString("\n")
+	}
+
 	m.viewport.Width = m.w
 	m.viewport.Height = m.h - m.frameTitle.HeaderHeight()
 	m.viewport.SetContent(viewportContent.String())
+	m.scrollToCursor()
+}
+
+func (m *Model) rowName(row itemRow) string {
+	marker := "  "
+	if len(row.subitems) > 0 {
+		if m.collapsed[row.path] {
+			marker = "▸ "
+		} else {
+			marker = "▾ "
+		}
+	}
+	return strings.Repeat("  ", row.depth) + marker + row.name
+}
+
+func (m *Model) scrollToCursor() {
+	if !m.focused {
+		return
+	}
+
+	if m.cursor < m.viewport.YOffset {
+		m.viewport.SetYOffset(m.cursor)
+	} else if m.viewport.Height > 0 && m.cursor >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(m.cursor - m.viewport.Height + 1)
+	}
 }
 
-func (m *Model) renderItem(w io.Writer, prefix string, name string, r itemrender.Renderer) {
-	fmt.Fprintf(w, "%s%v\t%s\t%s%s\n",
-		prefix, name, fieldTypeStyle.Render(r.TypeName()), r.StringValue(), metaInfoStyle.Render(r.MetaInfo()))
-	if subitems := r.SubItems(); len(subitems) > 0 {
-		for _, si := range subitems {
-			m.renderItem(w, prefix+"  ", si.Key, si.Value)
+func copyToClipboard(what string, text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.Write(text); err != nil {
+			return events.Error(err)
 		}
+		return events.StatusMsg("copied " + what + " to clipboard")
 	}
 }
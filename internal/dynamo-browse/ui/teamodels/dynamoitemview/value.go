@@ -0,0 +1,50 @@
+package dynamoitemview
+
+import (
+	"encoding/json"
+
+	"github.com/lmika/audax/internal/dynamo-browse/models/itemrender"
+)
+
+// rowValue returns the value of a row as text.  Values with sub-items are returned as JSON.
+func rowValue(r itemrender.Renderer) string {
+	if len(r.SubItems()) == 0 {
+		return r.StringValue()
+	}
+
+	bts, err := json.MarshalIndent(rendererToJSON(r), "", "  ")
+	if err != nil {
+		return r.StringValue()
+	}
+	return string(bts)
+}
+
+func rendererToJSON(r itemrender.Renderer) any {
+	subitems := r.SubItems()
+	if len(subitems) == 0 {
+		switch r.TypeName() {
+		case "N", "number":
+			return json.Number(r.StringValue())
+		case "BOOL", "bool":
+			return r.StringValue() == "True" || r.StringValue() == "true"
+		case "NULL", "null":
+			return nil
+		}
+		return r.StringValue()
+	}
+
+	switch r.TypeName() {
+	case "L", "SS", "NS", "BS", "array":
+		list := make([]any, len(subitems))
+		for i, si := range subitems {
+			list[i] = rendererToJSON(si.Value)
+		}
+		return list
+	}
+
+	m := make(map[string]any, len(subitems))
+	for _, si := range subitems {
+		m[si.Key] = rendererToJSON(si.Value)
+	}
+	return m
+}
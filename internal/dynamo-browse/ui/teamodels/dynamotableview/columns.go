@@ -0,0 +1,152 @@
+package dynamotableview
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/utils"
+	"github.com/muesli/reflow/truncate"
+)
+
+const (
+	// columnSampleSize is the number of items sampled to determine the width of each column
+	columnSampleSize = 200
+
+	minColumnWidth     = 3
+	maxAutoColumnWidth = 40
+	columnWidthStep    = 4
+
+	// statusColumnWidth is the width of the status column, including the separator
+	statusColumnWidth = 2
+)
+
+// frozenColumns returns the number of key columns which are always displayed.  The key columns are always the
+// first columns of the result set.
+func (m *Model) frozenColumns() int {
+	if m.resultSet == nil {
+		return 0
+	}
+
+	frozen := 1
+	if m.resultSet.TableInfo.Keys.SortKey != "" {
+		frozen = 2
+	}
+	return utils.Min(frozen, len(m.resultSet.Columns()))
+}
+
+// displayedColumns returns the names of the displayed columns: the frozen key columns followed by the other
+// columns from the column offset.
+func (m *Model) displayedColumns() []string {
+	if m.resultSet == nil {
+		return nil
+	}
+
+	columns := m.resultSet.Columns()
+	frozen := m.frozenColumns()
+
+	displayed := make([]string, 0, len(columns))
+	displayed = append(displayed, columns[:frozen]...)
+	if offset := utils.Max(m.colOffset, frozen); offset < len(columns) {
+		displayed = append(displayed, columns[offset:]...)
+	}
+	return displayed
+}
+
+// columnWidth returns the width of the column, either set by the user or determined from the sampled items.
+func (m *Model) columnWidth(colName string) int {
+	if w, hasWidth := m.colWidths[colName]; hasWidth {
+		return w
+	}
+	if w, hasWidth := m.autoColWidths[colName]; hasWidth {
+		return w
+	}
+	return utils.Max(lipgloss.Width(colName), minColumnWidth)
+}
+
+// sampleColumnWidths determines the width of each column from the header and a sample of the items.
+func (m *Model) sampleColumnWidths() {
+	m.autoColWidths = make(map[string]int)
+	if m.resultSet == nil {
+		return
+	}
+
+	items := m.resultSet.Items()
+	if len(items) > columnSampleSize {
+		items = items[:columnSampleSize]
+	}
+
+	for _, colName := range m.resultSet.Columns() {
+		width := lipgloss.Width(colName)
+		for _, item := range items {
+			if r := item.Renderer(colName); r != nil {
+				width = utils.Max(width, lipgloss.Width(r.StringValue())+lipgloss.Width(r.MetaInfo()))
+			}
+		}
+		m.autoColWidths[colName] = utils.Min(utils.Max(width, minColumnWidth), maxAutoColumnWidth)
+	}
+}
+
+// adjustColumnWidth widens or narrows the selected column.
+func (m *Model) adjustColumnWidth(delta int) {
+	colName := m.SelectedColumn()
+	if colName == "" {
+		return
+	}
+
+	m.colWidths[colName] = utils.Max(m.columnWidth(colName)+delta, minColumnWidth)
+	m.scrollToColCursor()
+	m.table.UpdateView()
+}
+
+// moveColCursor moves the cell cursor to the column, scrolling the non-frozen columns so that it is visible.
+func (m *Model) moveColCursor(newCol int) {
+	if m.resultSet == nil {
+		return
+	}
+
+	m.colCursor = utils.Max(utils.Min(newCol, len(m.resultSet.Columns())-1), 0)
+	m.scrollToColCursor()
+	m.table.UpdateView()
+}
+
+func (m *Model) scrollToColCursor() {
+	columns := m.resultSet.Columns()
+	frozen := m.frozenColumns()
+	if m.colCursor < frozen {
+		return
+	}
+
+	if m.colCursor < m.colOffset || m.colOffset < frozen {
+		m.colOffset = utils.Max(m.colCursor, frozen)
+		if m.colCursor < m.colOffset {
+			return
+		}
+	}
+
+	frozenWidth := statusColumnWidth
+	for _, colName := range columns[:frozen] {
+		frozenWidth += m.columnWidth(colName) + 1
+	}
+
+	for m.colOffset < m.colCursor {
+		width := frozenWidth
+		for _, colName := range columns[m.colOffset : m.colCursor+1] {
+			width += m.columnWidth(colName) + 1
+		}
+		if width <= m.w {
+			break
+		}
+		m.colOffset++
+	}
+}
+
+// fitToWidth truncates or pads the text so that it is exactly width cells wide.  Truncated text will end with
+// an ellipsis.
+func fitToWidth(text string, width int) string {
+	if textWidth := lipgloss.Width(text); textWidth > width {
+		return truncate.StringWithTail(text, uint(width), "…")
+	} else if textWidth < width {
+		return text + strings.Repeat(" ", width-textWidth)
+	}
+	return text
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lmika/audax/internal/dynamo-browse/controllers"
 	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/keybindings"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/dynamoitemview"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/frame"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/layout"
@@ -20,30 +21,25 @@ var (
 		Background(lipgloss.Color("#4479ff"))
 )
 
-type KeyBinding struct {
-	MoveUp   key.Binding
-	MoveDown key.Binding
-	PageUp   key.Binding
-	PageDown key.Binding
-	Home     key.Binding
-	End      key.Binding
-	ColLeft  key.Binding
-	ColRight key.Binding
-}
-
 type Model struct {
 	frameTitle frame.FrameTitle
 	table      table.Model
 	w, h       int
-	keyBinding KeyBinding
+	keyBinding *keybindings.TableKeyBinding
+	focused    bool
 
 	// model state
-	colOffset int
-	rows      []table.Row
-	resultSet *models.ResultSet
+	colOffset     int
+	colCursor     int
+	colWidths     map[string]int
+	autoColWidths map[string]int
+	rows          []table.Row
+	resultSet     *models.ResultSet
+	search        *models.Search
+	popup         *cellPopup
 }
 
-func New(uiStyles styles.Styles) *Model {
+func New(keyBinding *keybindings.TableKeyBinding, uiStyles styles.Styles) *Model {
 	tbl := table.New(table.SimpleColumns([]string{"pk", "sk"}), 100, 100)
 	rows := make([]table.Row, 0)
 	tbl.SetRows(rows)
@@ -53,16 +49,9 @@ func New(uiStyles styles.Styles) *Model {
 	return &Model{
 		frameTitle: frameTitle,
 		table:      tbl,
-		keyBinding: KeyBinding{
-			MoveUp:   key.NewBinding(key.WithKeys("i", "up")),
-			MoveDown: key.NewBinding(key.WithKeys("k", "down")),
-			PageUp:   key.NewBinding(key.WithKeys("I", "pgup")),
-			PageDown: key.NewBinding(key.WithKeys("K", "pgdown")),
-			Home:     key.NewBinding(key.WithKeys("0", "home")),
-			End:      key.NewBinding(key.WithKeys("$", "end")),
-			ColLeft:  key.NewBinding(key.WithKeys("j", "left")),
-			ColRight: key.NewBinding(key.WithKeys("l", "right")),
-		},
+		keyBinding: keyBinding,
+		focused:    true,
+		colWidths:  make(map[string]int),
 	}
 }
 
@@ -73,10 +62,19 @@ func (m *Model) Init() tea.Cmd {
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case controllers.NewResultSet:
+		if m.resultSet == nil || m.resultSet.TableInfo.Name != msg.ResultSet.TableInfo.Name {
+			m.colWidths = make(map[string]int)
+		}
 		m.resultSet = msg.ResultSet
 		m.updateTable()
 		return m, m.postSelectedItemChanged
 	case tea.KeyMsg:
+		if !m.focused {
+			break
+		} else if m.popup != nil {
+			return m, m.updatePopup(msg)
+		}
+
 		switch {
 		// Table nav
 		case key.Matches(msg, m.keyBinding.MoveUp):
@@ -98,29 +96,53 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.table.GoBottom()
 			return m, m.postSelectedItemChanged
 		case key.Matches(msg, m.keyBinding.ColLeft):
-			m.setLeftmostDisplayedColumn(m.colOffset - 1)
+			m.moveColCursor(m.colCursor - 1)
 			return m, nil
 		case key.Matches(msg, m.keyBinding.ColRight):
-			m.setLeftmostDisplayedColumn(m.colOffset + 1)
+			m.moveColCursor(m.colCursor + 1)
+			return m, nil
+		case key.Matches(msg, m.keyBinding.WidenColumn):
+			m.adjustColumnWidth(columnWidthStep)
+			return m, nil
+		case key.Matches(msg, m.keyBinding.NarrowColumn):
+			m.adjustColumnWidth(-columnWidthStep)
+			return m, nil
+		case key.Matches(msg, m.keyBinding.ShowCell):
+			m.showPopup()
 			return m, nil
+		case key.Matches(msg, m.keyBinding.NextMatch):
+			return m, m.NextMatch()
+		case key.Matches(msg, m.keyBinding.PrevMatch):
+			return m, m.PrevMatch()
 		}
 	}
 
 	return m, nil
 }
 
-func (m *Model) setLeftmostDisplayedColumn(newCol int) {
-	if newCol < 0 {
-		m.colOffset = 0
-	} else if newCol >= len(m.resultSet.Columns()) {
-		m.colOffset = len(m.resultSet.Columns()) - 1
-	} else {
-		m.colOffset = newCol
-	}
+// SetFocused sets whether the table has focus.  The table only responds to keys while focused.
+func (m *Model) SetFocused(focused bool) {
+	m.focused = focused
+	m.frameTitle.SetActive(focused)
 	m.table.UpdateView()
 }
 
+func (m *Model) Focused() bool {
+	return m.focused
+}
+
+// SelectedColumn returns the name of the column of the cell cursor.
+func (m *Model) SelectedColumn() string {
+	if m.resultSet == nil || m.colCursor >= len(m.resultSet.Columns()) {
+		return ""
+	}
+	return m.resultSet.Columns()[m.colCursor]
+}
+
 func (m *Model) View() string {
+	if m.popup != nil {
+		return lipgloss.JoinVertical(lipgloss.Top, m.frameTitle.View(), m.popup.view(m.w, m.h-m.frameTitle.HeaderHeight()))
+	}
 	return lipgloss.JoinVertical(lipgloss.Top, m.frameTitle.View(), m.table.View())
 }
 
@@ -134,6 +156,9 @@ func (m *Model) Resize(w, h int) layout.ResizingModel {
 
 func (m *Model) updateTable() {
 	m.colOffset = 0
+	m.colCursor = 0
+	m.popup = nil
+	m.sampleColumnWidths()
 
 	m.frameTitle.SetTitle("Table: " + m.resultSet.TableInfo.Name)
 	m.rebuildTable()
@@ -0,0 +1,78 @@
+package dynamotableview
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/utils"
+	"github.com/muesli/reflow/wrap"
+)
+
+var popupStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("63"))
+
+// cellPopup displays the full value of the selected cell
+type cellPopup struct {
+	title  string
+	value  string
+	offset int
+}
+
+// PopupVisible returns true if the cell popup is displayed.
+func (m *Model) PopupVisible() bool {
+	return m.popup != nil
+}
+
+func (m *Model) showPopup() {
+	item, ok := m.selectedItem()
+	colName := m.SelectedColumn()
+	if !ok || colName == "" {
+		return
+	}
+
+	av, hasValue := item.item[colName]
+	if !hasValue {
+		return
+	}
+
+	value, isString := models.AttributeValueToJSONValue(av).(string)
+	if !isString {
+		bts, err := json.MarshalIndent(models.AttributeValueToJSONValue(av), "", "  ")
+		if err != nil {
+			return
+		}
+		value = string(bts)
+	}
+
+	m.popup = &cellPopup{title: colName, value: value}
+}
+
+func (m *Model) updatePopup(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keyBinding.ShowCell), msg.String() == "esc", msg.String() == "q":
+		m.popup = nil
+	case key.Matches(msg, m.keyBinding.MoveUp):
+		m.popup.offset = utils.Max(m.popup.offset-1, 0)
+	case key.Matches(msg, m.keyBinding.MoveDown):
+		m.popup.offset++
+	}
+	return nil
+}
+
+func (p *cellPopup) view(w, h int) string {
+	// Allow for the border and the title
+	innerW, innerH := utils.Max(w-2, 1), utils.Max(h-3, 1)
+
+	lines := strings.Split(wrap.String(p.value, innerW), "\n")
+	p.offset = utils.Max(utils.Min(p.offset, len(lines)-innerH), 0)
+	lines = lines[p.offset:utils.Min(p.offset+innerH, len(lines))]
+
+	title := lipgloss.NewStyle().Bold(true).Render(p.title)
+	content := lipgloss.JoinVertical(lipgloss.Left, title, strings.Join(lines, "\n"))
+	return popupStyle.Width(innerW).Height(innerH + 1).Render(content)
+}
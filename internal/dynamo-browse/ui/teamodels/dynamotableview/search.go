@@ -0,0 +1,162 @@
+package dynamotableview
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/dynamo-browse/models"
+	"github.com/pkg/errors"
+)
+
+// PromptForSearch prompts for a search expression, highlighting the matching cells and moving to the first
+// matching row as the expression is entered.  Cancelling the prompt will clear the search.
+func (m *Model) PromptForSearch() tea.Cmd {
+	if m.resultSet == nil {
+		return events.SetError(errors.New("no result set"))
+	}
+
+	startCursor := m.table.Cursor()
+	return func() tea.Msg {
+		return events.PromptForInputMsg{
+			Prompt: "search: ",
+			OnChange: func(value string) tea.Cmd {
+				search, err := models.ParseSearch(value, m.resultSet.Columns())
+				if err != nil {
+					// The expression is likely incomplete so simply clear the search until it is valid
+					m.setSearch(nil)
+					return nil
+				}
+
+				m.setSearch(search)
+				m.moveCursorTo(startCursor)
+				m.jumpToMatch(0, true)
+				return m.postSelectedItemChanged
+			},
+			OnDone: func(value string) tea.Cmd {
+				m.moveCursorTo(startCursor)
+				return m.Search(value)
+			},
+			OnCancel: func() tea.Cmd {
+				m.setSearch(nil)
+				m.moveCursorTo(startCursor)
+				return m.postSelectedItemChanged
+			},
+		}
+	}
+}
+
+// Search highlights the cells matching the search expression and moves to the first matching row at or after
+// the selected row.  An empty expression will clear the search.
+func (m *Model) Search(expr string) tea.Cmd {
+	if expr == "" {
+		m.setSearch(nil)
+		return events.SetStatus("search cleared")
+	} else if m.resultSet == nil {
+		return events.SetError(errors.New("no result set"))
+	}
+
+	search, err := models.ParseSearch(expr, m.resultSet.Columns())
+	if err != nil {
+		return events.SetError(err)
+	}
+
+	m.setSearch(search)
+	return m.jumpToMatchCmd(0, true)
+}
+
+// NextMatch moves to the next row matching the search, wrapping around at the end of the table.
+func (m *Model) NextMatch() tea.Cmd {
+	return m.jumpToMatchCmd(1, true)
+}
+
+// PrevMatch moves to the previous row matching the search, wrapping around at the start of the table.
+func (m *Model) PrevMatch() tea.Cmd {
+	return m.jumpToMatchCmd(1, false)
+}
+
+func (m *Model) setSearch(search *models.Search) {
+	m.search = search
+	m.table.UpdateView()
+}
+
+func (m *Model) jumpToMatchCmd(offset int, forward bool) tea.Cmd {
+	if m.search == nil {
+		return events.SetError(errors.New("no search"))
+	}
+
+	matchIdx, matchCount := m.jumpToMatch(offset, forward)
+	if matchCount == 0 {
+		return events.SetError(errors.Errorf("no matches for '%v'", m.search.Expr))
+	}
+
+	return tea.Batch(
+		m.postSelectedItemChanged,
+		events.SetStatus(fmt.Sprintf("match %d of %d", matchIdx+1, matchCount)),
+	)
+}
+
+// jumpToMatch moves the cursor to the first matching row, starting offset rows from the selected row, in the
+// given direction.  It returns the index of the match and the total number of matching rows.
+func (m *Model) jumpToMatch(offset int, forward bool) (int, int) {
+	matchingRows := m.matchingRows()
+	if len(matchingRows) == 0 {
+		return -1, 0
+	}
+
+	cursor := m.table.Cursor()
+	if forward {
+		for i, row := range matchingRows {
+			if row >= cursor+offset {
+				m.moveCursorTo(row)
+				return i, len(matchingRows)
+			}
+		}
+		m.moveCursorTo(matchingRows[0])
+		return 0, len(matchingRows)
+	}
+
+	for i := len(matchingRows) - 1; i >= 0; i-- {
+		if matchingRows[i] <= cursor-offset {
+			m.moveCursorTo(matchingRows[i])
+			return i, len(matchingRows)
+		}
+	}
+	m.moveCursorTo(matchingRows[len(matchingRows)-1])
+	return len(matchingRows) - 1, len(matchingRows)
+}
+
+func (m *Model) matchingRows() []int {
+	if m.search == nil || m.resultSet == nil {
+		return nil
+	}
+
+	columns := m.resultSet.Columns()
+	matchingRows := make([]int, 0)
+	for i, row := range m.rows {
+		if m.search.MatchesItem(row.(itemTableRow).item, columns) {
+			matchingRows = append(matchingRows, i)
+		}
+	}
+	return matchingRows
+}
+
+// moveCursorTo moves the cursor to the row.  The table does not support setting the cursor directly, so this
+// moves a page at a time until the row is within a page of the cursor.
+func (m *Model) moveCursorTo(row int) {
+	pageSize := m.h - m.frameTitle.HeaderHeight() - 1
+	for m.table.Cursor() < row {
+		if row-m.table.Cursor() >= pageSize && pageSize > 0 {
+			m.table.GoPageDown()
+		} else {
+			m.table.GoDown()
+		}
+	}
+	for m.table.Cursor() > row {
+		if m.table.Cursor()-row >= pageSize && pageSize > 0 {
+			m.table.GoPageUp()
+		} else {
+			m.table.GoUp()
+		}
+	}
+}
@@ -20,6 +20,11 @@ var (
 
 	metaInfoStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#888888"))
+	selectedCellStyle = lipgloss.NewStyle().
+				Reverse(true)
+	searchMatchStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#000000")).
+				Background(lipgloss.Color("#eac610"))
 )
 
 type itemTableRow struct {
@@ -63,18 +68,36 @@ func (mtr itemTableRow) Render(w io.Writer, model table.Model, index int) {
 		sb.WriteString(metaInfoStyle.Render("⋅\t"))
 	}
 
-	for i, colName := range mtr.resultSet.Columns()[mtr.model.colOffset:] {
+	selectedColumn := ""
+	if index == model.Cursor() && mtr.model.focused {
+		selectedColumn = mtr.model.SelectedColumn()
+	}
+
+	for i, colName := range mtr.model.displayedColumns() {
 		if i > 0 {
 			sb.WriteString(style.Render("\t"))
 		}
 
+		cellStyle, cellMetaInfoStyle := style, metaInfoStyle
+		if search := mtr.model.search; search != nil && search.MatchesAttribute(mtr.item, colName) {
+			cellStyle = cellStyle.Copy().Inherit(searchMatchStyle)
+		}
+		if colName == selectedColumn {
+			cellStyle = cellStyle.Copy().Inherit(selectedCellStyle)
+			cellMetaInfoStyle = cellMetaInfoStyle.Copy().Inherit(selectedCellStyle)
+		}
+
+		width := mtr.model.columnWidth(colName)
 		if r := mtr.item.Renderer(colName); r != nil {
-			sb.WriteString(style.Render(r.StringValue()))
-			if mi := r.MetaInfo(); mi != "" {
-				sb.WriteString(metaInfoStyle.Render(mi))
+			value := r.StringValue()
+			if valueWidth := lipgloss.Width(value); valueWidth >= width {
+				sb.WriteString(cellStyle.Render(fitToWidth(value, width)))
+			} else {
+				sb.WriteString(cellStyle.Render(value))
+				sb.WriteString(cellMetaInfoStyle.Render(fitToWidth(r.MetaInfo(), width-valueWidth)))
 			}
 		} else {
-			sb.WriteString(metaInfoStyle.Render("~"))
+			sb.WriteString(cellMetaInfoStyle.Render(fitToWidth("~", width)))
 		}
 	}
 
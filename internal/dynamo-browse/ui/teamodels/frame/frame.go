@@ -22,8 +22,8 @@ type FrameTitle struct {
 }
 
 type Style struct {
-	ActiveTitle   lipgloss.Style
-	InactiveTitle lipgloss.Style
+	ActiveTitle   lipgloss.Style `config:"active-title"`
+	InactiveTitle lipgloss.Style `config:"inactive-title"`
 }
 
 func NewFrameTitle(header string, active bool, style Style) FrameTitle {
@@ -34,6 +34,10 @@ func (f *FrameTitle) SetTitle(title string) {
 	f.header = title
 }
 
+func (f *FrameTitle) SetActive(active bool) {
+	f.active = active
+}
+
 func (f FrameTitle) View() string {
 	return f.headerView()
 }
@@ -0,0 +1,108 @@
+package helpview
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lmika/audax/internal/common/ui/commandctrl"
+	"github.com/lmika/audax/internal/common/userconfig"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/frame"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/layout"
+)
+
+// Model is an overlay which lists the available commands and key bindings when help is requested.
+type Model struct {
+	submodel    tea.Model
+	frameTitle  frame.FrameTitle
+	viewport    viewport.Model
+	keyBindings []userconfig.BindingDescription
+
+	visible bool
+	w, h    int
+}
+
+func New(submodel tea.Model, keyBindings []userconfig.BindingDescription, style frame.Style) *Model {
+	return &Model{
+		submodel:    submodel,
+		frameTitle:  frame.NewFrameTitle("Help", true, style),
+		viewport:    viewport.New(0, 0),
+		keyBindings: keyBindings,
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.submodel.Init()
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case commandctrl.ShowHelpMsg:
+		m.visible = true
+		m.viewport.SetContent(HelpText(msg.Commands, m.keyBindings))
+		m.viewport.GotoTop()
+		return m, nil
+	case tea.KeyMsg:
+		if m.visible {
+			switch msg.String() {
+			case "esc", "q":
+				m.visible = false
+				return m, nil
+			}
+
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+	}
+
+	var cmd tea.Cmd
+	m.submodel, cmd = m.submodel.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) Visible() bool {
+	return m.visible
+}
+
+func (m *Model) View() string {
+	if m.visible {
+		return lipgloss.JoinVertical(lipgloss.Top, m.frameTitle.View(), m.viewport.View())
+	}
+	return m.submodel.View()
+}
+
+func (m *Model) Resize(w, h int) layout.ResizingModel {
+	m.w, m.h = w, h
+	m.submodel = layout.Resize(m.submodel, w, h)
+
+	m.frameTitle.Resize(w, h)
+	m.viewport.Width = w
+	m.viewport.Height = h - m.frameTitle.HeaderHeight()
+	return m
+}
+
+// HelpText returns the help listing the commands and key bindings.
+func HelpText(commands []commandctrl.CommandDescription, keyBindings []userconfig.BindingDescription) string {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "Commands")
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  :%v %v\t%v\n", cmd.Name, cmd.Usage, cmd.Description)
+	}
+
+	if len(keyBindings) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "Key Bindings")
+		for _, kb := range keyBindings {
+			fmt.Fprintf(tw, "  %v\t%v\n", kb.Name, strings.Join(kb.Keys, ", "))
+		}
+	}
+
+	tw.Flush()
+	return sb.String()
+}
@@ -1,5 +1,7 @@
 package layout
 
+import "github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/utils"
+
 type BoxSize interface {
 	childSize(idx, cnt, available int) int
 }
@@ -38,3 +40,45 @@ func (l lastChildFixedAt) childSize(idx, cnt, available int) int {
 	}
 	return (equalSize{}).childSize(idx, cnt-1, available-l.lastChildSize)
 }
+
+// AdjustableLastChild is a box size where the size of the last child can be changed while the program is
+// running.  The last child can also be maximised, in which case the other children will not be displayed.
+type AdjustableLastChild struct {
+	size         int
+	minOtherSize int
+	maximised    bool
+}
+
+// NewAdjustableLastChild returns an adjustable box size with the last child starting at size.  The other
+// children will get at least minOtherSize in total, unless the last child is maximised.
+func NewAdjustableLastChild(size int, minOtherSize int) *AdjustableLastChild {
+	return &AdjustableLastChild{size: size, minOtherSize: minOtherSize}
+}
+
+// Adjust changes the size of the last child by delta.  This will also restore the child if it is maximised.
+func (l *AdjustableLastChild) Adjust(delta int) {
+	l.maximised = false
+	l.size = utils.Max(l.size+delta, 1)
+}
+
+// ToggleMaximised maximises or restores the last child.
+func (l *AdjustableLastChild) ToggleMaximised() {
+	l.maximised = !l.maximised
+}
+
+// Maximised returns true if the last child is maximised.
+func (l *AdjustableLastChild) Maximised() bool {
+	return l.maximised
+}
+
+func (l *AdjustableLastChild) childSize(idx, cnt, available int) int {
+	lastChildSize := available
+	if !l.maximised {
+		lastChildSize = utils.Max(utils.Min(l.size, available-l.minOtherSize), 1)
+	}
+
+	if idx == cnt-1 {
+		return lastChildSize
+	}
+	return (equalSize{}).childSize(idx, cnt-1, available-lastChildSize)
+}
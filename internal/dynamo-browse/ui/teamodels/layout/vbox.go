@@ -11,6 +11,7 @@ import (
 type VBox struct {
 	boxSize  BoxSize
 	children []ResizingModel
+	w, h     int
 }
 
 func NewVBox(boxSize BoxSize, children ...ResizingModel) VBox {
@@ -26,6 +27,10 @@ func (vb VBox) Init() tea.Cmd {
 }
 
 func (vb VBox) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, isRelayout := msg.(RelayoutMsg); isRelayout {
+		return vb.Resize(vb.w, vb.h), nil
+	}
+
 	var cc utils.CmdCollector
 	for i, c := range vb.children {
 		vb.children[i] = cc.Collect(c.Update(msg)).(ResizingModel)
@@ -36,7 +41,10 @@ func (vb VBox) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (vb VBox) View() string {
 	sb := new(strings.Builder)
 	for i, c := range vb.children {
-		if i > 0 {
+		if vb.boxSize.childSize(i, len(vb.children), vb.h) <= 0 {
+			continue
+		}
+		if sb.Len() > 0 {
 			sb.WriteRune('\n')
 		}
 		sb.WriteString(c.View())
@@ -45,9 +53,21 @@ func (vb VBox) View() string {
 }
 
 func (vb VBox) Resize(w, h int) ResizingModel {
+	vb.w, vb.h = w, h
 	for i, c := range vb.children {
+		// Children which are not displayed keep their previous size
 		childHeight := vb.boxSize.childSize(i, len(vb.children), h)
-		vb.children[i] = c.Resize(w, childHeight)
+		if childHeight > 0 {
+			vb.children[i] = c.Resize(w, childHeight)
+		}
 	}
 	return vb
 }
+
+// RelayoutMsg requests that boxes resize their children, such as when a box size has been adjusted.
+type RelayoutMsg struct{}
+
+// Relayout is a command which resizes the children of any boxes.
+func Relayout() tea.Msg {
+	return RelayoutMsg{}
+}
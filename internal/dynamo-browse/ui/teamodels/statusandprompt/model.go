@@ -1,12 +1,12 @@
 package statusandprompt
 
 import (
-	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/lmika/audax/internal/common/sliceutils"
 	"github.com/lmika/audax/internal/common/ui/events"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/layout"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/utils"
+	"strings"
 )
 
 // StatusAndPrompt is a resizing model which displays a submodel and a status bar.  When the start prompt
@@ -15,19 +15,18 @@ type StatusAndPrompt struct {
 	model         layout.ResizingModel
 	style         Style
 	modeLine      string
+	contextLine   string
 	statusMessage string
-	pendingInput  *events.PromptForInputMsg
-	textInput     textinput.Model
+	prompt        *Prompt
 	width         int
 }
 
 type Style struct {
-	ModeLine lipgloss.Style
+	ModeLine lipgloss.Style `config:"mode-line"`
 }
 
 func New(model layout.ResizingModel, initialMsg string, style Style) *StatusAndPrompt {
-	textInput := textinput.New()
-	return &StatusAndPrompt{model: model, style: style, statusMessage: initialMsg, modeLine: "", textInput: textInput}
+	return &StatusAndPrompt{model: model, style: style, statusMessage: initialMsg, modeLine: "", prompt: NewPrompt()}
 }
 
 func (s *StatusAndPrompt) Init() tea.Cmd {
@@ -42,43 +41,20 @@ func (s *StatusAndPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		s.statusMessage = string(msg)
 	case events.ModeMessage:
 		s.modeLine = string(msg)
+	case events.StatusContextMsg:
+		s.contextLine = string(msg)
 	case events.MessageWithStatus:
 		if hasModeMessage, ok := msg.(events.MessageWithMode); ok {
 			s.modeLine = hasModeMessage.ModeMessage()
 		}
 		s.statusMessage = msg.StatusMessage()
 	case events.PromptForInputMsg:
-		if s.pendingInput != nil {
-			// ignore, already in an input
-			return s, nil
-		}
-
-		s.textInput.Prompt = msg.Prompt
-		s.textInput.Focus()
-		s.textInput.SetValue("")
-		s.pendingInput = &msg
-		return s, nil
+		return s, s.prompt.Start(msg)
 	case tea.KeyMsg:
-		if s.pendingInput != nil {
-			switch msg.Type {
-			case tea.KeyCtrlC, tea.KeyEsc:
-				s.pendingInput = nil
-			case tea.KeyEnter:
-				pendingInput := s.pendingInput
-				s.pendingInput = nil
-
-				return s, pendingInput.OnDone(s.textInput.Value())
-			default:
-				if msg.Type == tea.KeyRunes {
-					msg.Runes = sliceutils.Filter(msg.Runes, func(r rune) bool { return r != '\x0d' && r != '\x0a' })
-				}
-				newTextInput, cmd := s.textInput.Update(msg)
-				s.textInput = newTextInput
-				return s, cmd
-			}
-		} else {
-			s.statusMessage = ""
+		if s.prompt.InPrompt() {
+			return s, s.prompt.Update(msg)
 		}
+		s.statusMessage = ""
 	}
 
 	newModel, cmd := s.model.Update(msg)
@@ -87,7 +63,7 @@ func (s *StatusAndPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (s *StatusAndPrompt) InPrompt() bool {
-	return s.pendingInput != nil
+	return s.prompt.InPrompt()
 }
 
 func (s *StatusAndPrompt) View() string {
@@ -102,11 +78,18 @@ func (s *StatusAndPrompt) Resize(w, h int) layout.ResizingModel {
 }
 
 func (s *StatusAndPrompt) viewStatus() string {
-	modeLine := s.style.ModeLine.Render(lipgloss.PlaceHorizontal(s.width, lipgloss.Left, s.modeLine, lipgloss.WithWhitespaceChars(" ")))
+	modeLineText := s.modeLine
+	if completionsLine := s.prompt.CompletionsLine(); completionsLine != "" {
+		modeLineText = completionsLine
+	} else if s.contextLine != "" {
+		gap := utils.Max(1, s.width-lipgloss.Width(s.modeLine)-lipgloss.Width(s.contextLine))
+		modeLineText = s.modeLine + strings.Repeat(" ", gap) + s.contextLine
+	}
+	modeLine := s.style.ModeLine.Render(lipgloss.PlaceHorizontal(s.width, lipgloss.Left, modeLineText, lipgloss.WithWhitespaceChars(" ")))
 
 	var statusLine string
-	if s.pendingInput != nil {
-		statusLine = s.textInput.View()
+	if s.prompt.InPrompt() {
+		statusLine = s.prompt.View()
 	} else {
 		statusLine = s.statusMessage
 	}
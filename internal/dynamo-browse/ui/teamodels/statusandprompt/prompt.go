@@ -0,0 +1,195 @@
+package statusandprompt
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/sliceutils"
+	"github.com/lmika/audax/internal/common/ui/events"
+)
+
+// Prompt is a text input which reads the value requested by a PromptForInputMsg, supporting the recall of
+// previously entered values and the completion of the input.
+type Prompt struct {
+	pendingInput  *events.PromptForInputMsg
+	historyIdx    int
+	completions   []string
+	completionIdx int
+	textInput     textinput.Model
+}
+
+func NewPrompt() *Prompt {
+	return &Prompt{textInput: textinput.New()}
+}
+
+// Start shows the prompt for input.  If a prompt is already being shown, the new prompt will be cancelled.
+func (p *Prompt) Start(msg events.PromptForInputMsg) tea.Cmd {
+	if p.pendingInput != nil {
+		// already in an input, so the new prompt cannot be shown
+		if msg.OnCancel != nil {
+			return msg.OnCancel()
+		}
+		return nil
+	}
+
+	p.textInput.Prompt = msg.Prompt
+	p.textInput.Focus()
+	p.textInput.SetValue(msg.InitialValue)
+	p.textInput.CursorEnd()
+	p.pendingInput = &msg
+	p.historyIdx = len(msg.History)
+	p.completions = nil
+	return nil
+}
+
+// InPrompt returns true if the prompt is being shown.
+func (p *Prompt) InPrompt() bool {
+	return p.pendingInput != nil
+}
+
+func (p *Prompt) SetWidth(w int) {
+	p.textInput.Width = w
+}
+
+// Update handles the message.  Key messages are ignored unless the prompt is being shown.
+func (p *Prompt) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, isKeyMsg := msg.(tea.KeyMsg)
+	if !isKeyMsg {
+		var cmd tea.Cmd
+		p.textInput, cmd = p.textInput.Update(msg)
+		return cmd
+	} else if p.pendingInput == nil {
+		return nil
+	}
+
+	valueBefore := p.textInput.Value()
+	if keyMsg.Type != tea.KeyTab {
+		p.completions = nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		pendingInput := p.pendingInput
+		p.pendingInput = nil
+
+		if pendingInput.OnCancel != nil {
+			return pendingInput.OnCancel()
+		}
+		return nil
+	case tea.KeyEnter:
+		pendingInput := p.pendingInput
+		p.pendingInput = nil
+
+		return pendingInput.OnDone(p.textInput.Value())
+	case tea.KeyUp:
+		if p.historyIdx > 0 {
+			p.historyIdx--
+			p.setInput(p.pendingInput.History[p.historyIdx])
+		}
+		return p.inputChanged(valueBefore)
+	case tea.KeyDown:
+		if p.historyIdx < len(p.pendingInput.History)-1 {
+			p.historyIdx++
+			p.setInput(p.pendingInput.History[p.historyIdx])
+		} else if p.historyIdx == len(p.pendingInput.History)-1 {
+			p.historyIdx++
+			p.textInput.SetValue("")
+		}
+		return p.inputChanged(valueBefore)
+	case tea.KeyTab:
+		p.complete()
+		return p.inputChanged(valueBefore)
+	}
+
+	if keyMsg.Type == tea.KeyRunes {
+		keyMsg.Runes = sliceutils.Filter(keyMsg.Runes, func(r rune) bool { return r != '\x0d' && r != '\x0a' })
+	}
+	newTextInput, cmd := p.textInput.Update(keyMsg)
+	p.textInput = newTextInput
+	return tea.Batch(cmd, p.inputChanged(valueBefore))
+}
+
+func (p *Prompt) View() string {
+	return p.textInput.View()
+}
+
+// CompletionsLine returns the completions being cycled through, with the selected completion highlighted.  Returns
+// the empty string if there are no completions.
+func (p *Prompt) CompletionsLine() string {
+	if len(p.completions) == 0 {
+		return ""
+	}
+
+	wordStart := strings.LastIndex(commonPrefix(p.completions), " ") + 1
+
+	words := make([]string, len(p.completions))
+	for i, c := range p.completions {
+		if i == p.completionIdx {
+			words[i] = "[" + c[wordStart:] + "]"
+		} else {
+			words[i] = c[wordStart:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// inputChanged notifies the pending input of changes to the value
+func (p *Prompt) inputChanged(valueBefore string) tea.Cmd {
+	if p.pendingInput == nil || p.pendingInput.OnChange == nil || p.textInput.Value() == valueBefore {
+		return nil
+	}
+	return p.pendingInput.OnChange(p.textInput.Value())
+}
+
+// complete completes the current input.  A single completion will replace the input.  Multiple completions will
+// complete up to the common prefix, after which each completion will be cycled through.
+func (p *Prompt) complete() {
+	if p.pendingInput.Completer == nil {
+		return
+	}
+
+	if p.completions != nil {
+		p.completionIdx = (p.completionIdx + 1) % len(p.completions)
+		p.setInput(p.completions[p.completionIdx])
+		return
+	}
+
+	completions := p.pendingInput.Completer(p.textInput.Value())
+	switch len(completions) {
+	case 0:
+		return
+	case 1:
+		if completion := completions[0]; strings.HasSuffix(completion, string(filepath.Separator)) {
+			p.setInput(completion)
+		} else {
+			p.setInput(completion + " ")
+		}
+		return
+	}
+
+	if prefix := commonPrefix(completions); len(prefix) > len(p.textInput.Value()) {
+		p.setInput(prefix)
+		return
+	}
+
+	p.completions = completions
+	p.completionIdx = 0
+	p.setInput(completions[0])
+}
+
+func (p *Prompt) setInput(value string) {
+	p.textInput.SetValue(value)
+	p.textInput.CursorEnd()
+}
+
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
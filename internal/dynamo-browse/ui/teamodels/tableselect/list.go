@@ -5,7 +5,9 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/keybindings"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/layout"
+	"strings"
 )
 
 var (
@@ -21,7 +23,7 @@ type listController struct {
 	list list.Model
 }
 
-func newListController(tableNames []string, w, h int) listController {
+func newListController(tableNames []string, keyBinding *keybindings.TableKeyBinding, w, h int) listController {
 	items := toListItems(tableNames)
 
 	delegate := list.NewDefaultDelegate()
@@ -33,27 +35,24 @@ func newListController(tableNames []string, w, h int) listController {
 		Padding(0, 0, 0, 1)
 
 	list := list.New(items, delegate, w, h)
-	list.KeyMap.CursorUp = key.NewBinding(
-		key.WithKeys("up", "i"),
-		key.WithHelp("↑/i", "up"),
-	)
-	list.KeyMap.CursorDown = key.NewBinding(
-		key.WithKeys("down", "k"),
-		key.WithHelp("↓/k", "down"),
-	)
-	list.KeyMap.PrevPage = key.NewBinding(
-		key.WithKeys("left", "j", "pgup", "b", "u"),
-		key.WithHelp("←/j/pgup", "prev page"),
-	)
-	list.KeyMap.NextPage = key.NewBinding(
-		key.WithKeys("right", "l", "pgdown", "f", "d"),
-		key.WithHelp("→/l/pgdn", "next page"),
-	)
+	list.KeyMap.CursorUp = keyBinding.MoveUp
+	list.KeyMap.CursorDown = keyBinding.MoveDown
+	list.KeyMap.PrevPage = combinedBinding("prev page", keyBinding.ColLeft, keyBinding.PageUp)
+	list.KeyMap.NextPage = combinedBinding("next page", keyBinding.ColRight, keyBinding.PageDown)
 	list.SetShowTitle(false)
 
 	return listController{list: list}
 }
 
+// combinedBinding returns a binding which matches any of the keys of the passed in bindings
+func combinedBinding(desc string, bindings ...key.Binding) key.Binding {
+	var keys []string
+	for _, b := range bindings {
+		keys = append(keys, b.Keys()...)
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(strings.Join(keys, "/"), desc))
+}
+
 func (l listController) Init() tea.Cmd {
 	return nil
 }
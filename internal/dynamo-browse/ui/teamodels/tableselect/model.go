@@ -5,6 +5,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lmika/audax/internal/dynamo-browse/controllers"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/keybindings"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/frame"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/layout"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/styles"
@@ -24,12 +25,13 @@ type Model struct {
 	submodel         tea.Model
 	pendingSelection *controllers.PromptForTableMsg
 	isLoading        bool
+	keyBinding       *keybindings.TableKeyBinding
 	w, h             int
 }
 
-func New(submodel tea.Model, uiStyles styles.Styles) *Model {
+func New(submodel tea.Model, keyBinding *keybindings.TableKeyBinding, uiStyles styles.Styles) *Model {
 	frameTitle := frame.NewFrameTitle("Select table", false, uiStyles.Frames)
-	return &Model{frameTitle: frameTitle, submodel: submodel}
+	return &Model{frameTitle: frameTitle, submodel: submodel, keyBinding: keyBinding}
 }
 
 func (m *Model) Init() tea.Cmd {
@@ -42,8 +44,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case controllers.PromptForTableMsg:
 		m.isLoading = false
 		m.pendingSelection = &msg
-		m.listController = newListController(msg.Tables, m.w, m.h-m.frameTitle.HeaderHeight())
+		m.listController = newListController(msg.Tables, m.keyBinding, m.w, m.h-m.frameTitle.HeaderHeight())
 		return m, nil
+	case controllers.NewResultSet:
+		// A table was selected by other means, such as via a command
+		m.isLoading = false
+		m.pendingSelection = nil
 	case indicateLoadingTablesMsg:
 		m.isLoading = true
 		return m, nil
@@ -82,6 +88,11 @@ func (m *Model) Visible() bool {
 	return m.pendingSelection != nil || m.isLoading
 }
 
+// Filtering returns true if the user is currently entering a filter for the table list.
+func (m *Model) Filtering() bool {
+	return m.pendingSelection != nil && m.listController.list.FilterState() == list.Filtering
+}
+
 func (m *Model) Resize(w, h int) layout.ResizingModel {
 	m.w, m.h = w, h
 	m.submodel = layout.Resize(m.submodel, w, h)
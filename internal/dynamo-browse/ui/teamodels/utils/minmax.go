@@ -6,3 +6,10 @@ func Max(x, y int) int {
 	}
 	return y
 }
+
+func Min(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
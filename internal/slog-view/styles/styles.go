@@ -7,8 +7,8 @@ import (
 )
 
 type Styles struct {
-	Frames          frame.Style
-	StatusAndPrompt statusandprompt.Style
+	Frames          frame.Style           `config:"frames"`
+	StatusAndPrompt statusandprompt.Style `config:"status"`
 }
 
 var DefaultStyles = Styles{
@@ -0,0 +1,35 @@
+package keybindings
+
+import "github.com/charmbracelet/bubbles/key"
+
+// KeyBindings are the key bindings used by slog-view.  Each binding can be rebound from the user
+// config file using the name within the "config" tags, such as "list.move-up".
+type KeyBindings struct {
+	List *ListKeyBinding  `config:"list"`
+	View *ViewKeyBindings `config:"view"`
+}
+
+type ListKeyBinding struct {
+	MoveUp   key.Binding `config:"move-up"`
+	MoveDown key.Binding `config:"move-down"`
+}
+
+type ViewKeyBindings struct {
+	PromptForCommand key.Binding `config:"prompt-for-command"`
+	ViewFullScreen   key.Binding `config:"view-full-screen"`
+	Quit             key.Binding `config:"quit"`
+}
+
+func Default() *KeyBindings {
+	return &KeyBindings{
+		List: &ListKeyBinding{
+			MoveUp:   key.NewBinding(key.WithKeys("i", "up")),
+			MoveDown: key.NewBinding(key.WithKeys("k", "down")),
+		},
+		View: &ViewKeyBindings{
+			PromptForCommand: key.NewBinding(key.WithKeys(":")),
+			ViewFullScreen:   key.NewBinding(key.WithKeys("w")),
+			Quit:             key.NewBinding(key.WithKeys("ctrl+c", "q")),
+		},
+	}
+}
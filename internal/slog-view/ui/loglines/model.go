@@ -1,11 +1,13 @@
 package loglines
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/frame"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/layout"
 	"github.com/lmika/audax/internal/slog-view/models"
+	"github.com/lmika/audax/internal/slog-view/ui/keybindings"
 	table "github.com/lmika/go-bubble-table"
 	"path/filepath"
 )
@@ -16,16 +18,18 @@ type Model struct {
 
 	logFile *models.LogFile
 
-	w, h int
+	keyBinding *keybindings.ListKeyBinding
+	w, h       int
 }
 
-func New(style frame.Style) *Model {
+func New(keyBinding *keybindings.ListKeyBinding, style frame.Style) *Model {
 	frameTitle := frame.NewFrameTitle("File: ", true, style)
 	table := table.New(table.SimpleColumns{"level", "error", "message"}, 0, 0)
 
 	return &Model{
 		frameTitle: frameTitle,
 		table:      table,
+		keyBinding: keyBinding,
 	}
 }
 
@@ -53,11 +57,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	//var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "i", "up":
+		switch {
+		case key.Matches(msg, m.keyBinding.MoveUp):
 			m.table.GoUp()
 			return m, m.emitNewSelectedParameter()
-		case "k", "down":
+		case key.Matches(msg, m.keyBinding.MoveDown):
 			m.table.GoDown()
 			return m, m.emitNewSelectedParameter()
 		}
@@ -1,13 +1,17 @@
 package ui
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lmika/audax/internal/common/ui/commandctrl"
+	"github.com/lmika/audax/internal/common/userconfig"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/helpview"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/layout"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/statusandprompt"
 	"github.com/lmika/audax/internal/slog-view/controllers"
 	"github.com/lmika/audax/internal/slog-view/styles"
 	"github.com/lmika/audax/internal/slog-view/ui/fullviewlinedetails"
+	"github.com/lmika/audax/internal/slog-view/ui/keybindings"
 	"github.com/lmika/audax/internal/slog-view/ui/linedetails"
 	"github.com/lmika/audax/internal/slog-view/ui/loglines"
 )
@@ -21,15 +25,17 @@ type Model struct {
 	lineDetails         *linedetails.Model
 	statusAndPrompt     *statusandprompt.StatusAndPrompt
 	fullViewLineDetails *fullviewlinedetails.Model
+	helpView            *helpview.Model
+	keyBindings         *keybindings.KeyBindings
 }
 
-func NewModel(controller *controllers.LogFileController, cmdController *commandctrl.CommandController) Model {
-	defaultStyles := styles.DefaultStyles
-	logLines := loglines.New(defaultStyles.Frames)
-	lineDetails := linedetails.New(defaultStyles.Frames)
+func NewModel(controller *controllers.LogFileController, cmdController *commandctrl.CommandController, keyBindings *keybindings.KeyBindings, uiStyles styles.Styles) Model {
+	logLines := loglines.New(keyBindings.List, uiStyles.Frames)
+	lineDetails := linedetails.New(uiStyles.Frames)
 	box := layout.NewVBox(layout.LastChildFixedAt(17), logLines, lineDetails)
-	fullViewLineDetails := fullviewlinedetails.NewModel(box, defaultStyles.Frames)
-	statusAndPrompt := statusandprompt.New(fullViewLineDetails, "", defaultStyles.StatusAndPrompt)
+	fullViewLineDetails := fullviewlinedetails.NewModel(box, uiStyles.Frames)
+	helpView := helpview.New(fullViewLineDetails, userconfig.DescribeKeyBindings(keyBindings), uiStyles.Frames)
+	statusAndPrompt := statusandprompt.New(helpView, "", uiStyles.StatusAndPrompt)
 
 	root := layout.FullScreen(statusAndPrompt)
 
@@ -41,11 +47,13 @@ func NewModel(controller *controllers.LogFileController, cmdController *commandc
 		logLines:            logLines,
 		lineDetails:         lineDetails,
 		fullViewLineDetails: fullViewLineDetails,
+		helpView:            helpView,
+		keyBindings:         keyBindings,
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return m.controller.ReadLogFile()
+	return m.cmdController.Sequence(m.controller.ReadLogFile(), m.cmdController.Init())
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -58,16 +66,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.lineDetails.SetSelectedItem(msg)
 
 	case tea.KeyMsg:
-		if !m.statusAndPrompt.InPrompt() {
-			switch msg.String() {
+		if !m.statusAndPrompt.InPrompt() && !m.helpView.Visible() {
+			switch {
 			// TEMP
-			case ":":
+			case key.Matches(msg, m.keyBindings.View.PromptForCommand):
 				return m, m.cmdController.Prompt()
-			case "w":
+			case key.Matches(msg, m.keyBindings.View.ViewFullScreen):
 				return m, m.controller.ViewLogLineFullScreen(m.logLines.SelectedLogLine())
 			// END TEMP
 
-			case "ctrl+c", "q":
+			case key.Matches(msg, m.keyBindings.View.Quit):
 				return m, tea.Quit
 			}
 		}
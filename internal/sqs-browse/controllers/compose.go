@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/pkg/errors"
+)
+
+// maxReportedSendFailures is the maximum number of failures listed when sending a batch of messages
+const maxReportedSendFailures = 3
+
+// ComposeMessage sends a new message to the destination queue.  If the message has no body, the message is
+// first written in the user's editor.
+func (msh *MessageSendingController) ComposeMessage(message models.Message, destQueue string, opts models.SendOptions) tea.Cmd {
+	if message.Data != "" {
+		return msh.send(message, destQueue, opts)
+	}
+
+	return openInEditor(message, func(editedMessage models.Message) tea.Cmd {
+		return msh.send(editedMessage, destQueue, opts)
+	})
+}
+
+// ComposeMessageFromFile sends a new message with the body read from a file.
+func (msh *MessageSendingController) ComposeMessageFromFile(message models.Message, filename string, destQueue string, opts models.SendOptions) tea.Cmd {
+	body, err := os.ReadFile(filename)
+	if err != nil {
+		return events.SetError(errors.Wrapf(err, "cannot read %v", filename))
+	}
+
+	message.Data = strings.TrimRight(string(body), "\r\n")
+	if message.Data == "" {
+		return events.SetError(errors.Errorf("%v is empty", filename))
+	}
+	return msh.send(message, destQueue, opts)
+}
+
+// SendMessagesFromFile sends the messages of a JSONL file to the destination queue in batches.  Each line is either
+// a message record or the body of a message.  The attributes and group ID of the template message are used for
+// messages that do not specify their own.
+func (msh *MessageSendingController) SendMessagesFromFile(template models.Message, filename string, destQueue string, opts models.SendOptions) tea.Cmd {
+	return func() tea.Msg {
+		msgs, err := readMessageFile(filename)
+		if err != nil {
+			return events.Error(err)
+		}
+
+		for i, msg := range msgs {
+			if len(msg.Attributes) == 0 {
+				msgs[i].Attributes = template.Attributes
+			}
+			if msg.MessageGroupID == "" {
+				msgs[i].MessageGroupID = template.MessageGroupID
+			}
+		}
+
+		sendErrs, err := msh.messageService.SendBatch(context.Background(), msgs, destQueue, opts)
+		if err != nil {
+			return events.Error(err)
+		}
+
+		var failures []string
+		for i, sendErr := range sendErrs {
+			if sendErr == nil {
+				continue
+			}
+			log.Printf("cannot send message %d of %v: %v", i+1, filename, sendErr)
+			if len(failures) < maxReportedSendFailures {
+				failures = append(failures, fmt.Sprintf("message %d: %v", i+1, sendErr))
+			}
+		}
+
+		queueName := models.QueueName(destQueue)
+		failureCount := countErrors(sendErrs)
+		if failureCount == 0 {
+			return events.StatusMsg(fmt.Sprintf("Sent %d messages to %v", len(msgs), queueName))
+		} else if failureCount > len(failures) {
+			failures = append(failures, fmt.Sprintf("and %d more", failureCount-len(failures)))
+		}
+		return events.StatusMsg(fmt.Sprintf("Sent %d of %d messages to %v, failures: %v",
+			len(msgs)-failureCount, len(msgs), queueName, strings.Join(failures, "; ")))
+	}
+}
+
+func readMessageFile(filename string) ([]models.Message, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open %v", filename)
+	}
+	defer f.Close()
+
+	var msgs []models.Message
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		msg, err := models.ParseMessageRecord(scanner.Bytes())
+		if err != nil {
+			return nil, errors.Wrapf(err, "%v:%d", filename, lineNo)
+		}
+		msgs = append(msgs, msg)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "cannot read %v", filename)
+	}
+
+	if len(msgs) == 0 {
+		return nil, errors.Errorf("%v has no messages", filename)
+	}
+	return msgs, nil
+}
+
+func countErrors(errs []error) int {
+	count := 0
+	for _, err := range errs {
+		if err != nil {
+			count++
+		}
+	}
+	return count
+}
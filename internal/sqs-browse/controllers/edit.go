@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/editor"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/pkg/errors"
+)
+
+// EditMessage opens the attributes and body of the message in the user's editor, then sends the edited message
+// to the destination queue.  The destination queue defaults to the target queue, or the queue the message was
+// received from if there is no target queue.  If delay is negative, the user is prompted for the delay,
+// unless the destination is a FIFO queue.
+func (msh *MessageSendingController) EditMessage(message models.Message, destQueue string, delay time.Duration) tea.Cmd {
+	return openInEditor(message, func(editedMessage models.Message) tea.Cmd {
+		return msh.sendEdited(editedMessage, destQueue, delay)
+	})
+}
+
+// EditMessageInline prompts for a new body of the message, then sends the edited message to the destination
+// queue.  JSON bodies are presented on a single line.
+func (msh *MessageSendingController) EditMessageInline(message models.Message, destQueue string, delay time.Duration) tea.Cmd {
+	body := message.Data
+	if compactBody := new(bytes.Buffer); json.Compact(compactBody, []byte(body)) == nil {
+		body = compactBody.String()
+	}
+
+	return func() tea.Msg {
+		return events.PromptForInputMsg{
+			Prompt:       "body: ",
+			InitialValue: body,
+			OnDone: func(value string) tea.Cmd {
+				if err := models.ValidateEditedBody(message, value); err != nil {
+					return events.SetError(err)
+				}
+
+				editedMessage := message
+				editedMessage.Data = value
+				return msh.sendEdited(editedMessage, destQueue, delay)
+			},
+		}
+	}
+}
+
+func (msh *MessageSendingController) sendEdited(message models.Message, destQueue string, delay time.Duration) tea.Cmd {
+	if destQueue == "" {
+		destQueue = msh.targetQueue
+	}
+	if destQueue == "" {
+		destQueue = message.Queue
+	}
+
+	if delay >= 0 {
+		return msh.send(message, destQueue, models.SendOptions{Delay: delay})
+	} else if models.IsFIFOQueue(destQueue) {
+		// Delays cannot be set on messages sent to FIFO queues
+		return msh.send(message, destQueue, models.SendOptions{})
+	}
+
+	prompt := fmt.Sprintf("send to %v with delay in seconds (blank for none): ", models.QueueName(destQueue))
+	return events.PromptForInput(prompt, func(value string) tea.Cmd {
+		delay, err := parseDelay(value)
+		if err != nil {
+			return events.SetError(err)
+		}
+		return msh.send(message, destQueue, models.SendOptions{Delay: delay})
+	})
+}
+
+func (msh *MessageSendingController) send(message models.Message, destQueue string, opts models.SendOptions) tea.Cmd {
+	return func() tea.Msg {
+		messageId, err := msh.messageService.SendTo(context.Background(), message, destQueue, opts)
+		if err != nil {
+			return events.Error(err)
+		}
+		return events.StatusMsg("Message sent to " + models.QueueName(destQueue) + ", id = " + messageId)
+	}
+}
+
+// parseDelay parses a delay in seconds.  A blank value is no delay.
+func parseDelay(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.Errorf("invalid delay: %v", value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// openInEditor opens the attributes and body of the message in the user's editor.  Once the editor exits, the
+// edited message is passed to onEdited.
+func openInEditor(message models.Message, onEdited func(editedMessage models.Message) tea.Cmd) tea.Cmd {
+	editFile, err := os.CreateTemp("", "sqs-browse-*.txt")
+	if err != nil {
+		return events.SetError(errors.Wrap(err, "cannot create edit file"))
+	}
+	defer editFile.Close()
+
+	if _, err := editFile.WriteString(models.FormatForEdit(message)); err != nil {
+		os.Remove(editFile.Name())
+		return events.SetError(errors.Wrap(err, "cannot write edit file"))
+	}
+
+	return tea.ExecProcess(editor.Command(editFile.Name()), func(err error) tea.Msg {
+		defer os.Remove(editFile.Name())
+		if err != nil {
+			return events.Error(errors.Wrap(err, "editor failed"))
+		}
+
+		editedText, err := os.ReadFile(editFile.Name())
+		if err != nil {
+			return events.Error(errors.Wrap(err, "cannot read edit file"))
+		}
+
+		editedMessage, err := models.ParseEdited(message, string(editedText))
+		if err != nil {
+			return events.Error(err)
+		}
+		return onEdited(editedMessage)()
+	})
+}
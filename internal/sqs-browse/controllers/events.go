@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/models/filterexpr"
+)
+
+// MessageDeleted indicates that a message has been deleted from the queue.
+type MessageDeleted struct {
+	Message models.Message
+}
+
+// WorkspaceLoaded indicates that the messages previously captured in the workspace have been loaded.
+type WorkspaceLoaded struct {
+	Messages []models.Message
+}
+
+// WorkspaceFiltered indicates that the filter has changed, along with the messages in the workspace matching it.
+// The filter is nil if it has been cleared.
+type WorkspaceFiltered struct {
+	Filter   *filterexpr.FilterExpr
+	Messages []models.Message
+}
+
+// MessageUpdated indicates that the tags or note of a message within the workspace have changed.
+type MessageUpdated struct {
+	Message models.Message
+}
+
+// MessageRemoved indicates that a message has been removed from the workspace.
+type MessageRemoved struct {
+	Message models.Message
+}
+
+// MessagesRedriven reports the outcome of redriving messages to a queue.
+type MessagesRedriven struct {
+	Queue    string
+	Redriven []models.Message
+	Failures []RedriveFailure
+}
+
+// RedriveFailure is a message which could not be redriven.
+type RedriveFailure struct {
+	Message models.Message
+	Err     error
+}
+
+// PromptForQueueMsg requests that the user selects a queue.
+type PromptForQueueMsg struct {
+	Queues     []models.QueueInfo
+	OnSelected func(queueURL string) tea.Cmd
+}
+
+// QueueSelected indicates that polling has started on a new queue.
+type QueueSelected struct {
+	Queue string
+}
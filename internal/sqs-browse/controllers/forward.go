@@ -29,7 +29,7 @@ func (msh *MessageSendingController) ForwardMessage(message models.Message) uimo
 			return errors.New("target queue not set")
 		}
 
-		messageId, err := msh.messageService.SendTo(ctx, message, msh.targetQueue)
+		messageId, err := msh.messageService.SendTo(ctx, message, msh.targetQueue, models.SendOptions{})
 		if err != nil {
 			return errors.Wrapf(err, "cannot send message to %v", msh.targetQueue)
 		}
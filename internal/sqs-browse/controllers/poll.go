@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/common/ui/uimodels"
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/services/pollmessage"
+	"github.com/lmika/audax/internal/sqs-browse/services/queues"
+)
+
+type PollController struct {
+	pollService  *pollmessage.Service
+	queueService *queues.Service
+	initialQueue string
+}
+
+func NewPollController(pollService *pollmessage.Service, queueService *queues.Service, initialQueue string) *PollController {
+	return &PollController{
+		pollService:  pollService,
+		queueService: queueService,
+		initialQueue: initialQueue,
+	}
+}
+
+// Init starts polling the initial queue.  If no queue is specified, it prompts for the queue to poll.
+func (pc *PollController) Init() tea.Cmd {
+	if pc.initialQueue == "" {
+		return pc.ListQueues()
+	}
+	return pc.startPolling(pc.initialQueue)
+}
+
+func (pc *PollController) Queue() string {
+	return pc.pollService.Queue()
+}
+
+func (pc *PollController) Mode() models.PollMode {
+	return pc.pollService.Mode()
+}
+
+// ListQueues prompts for the queue to poll.
+func (pc *PollController) ListQueues() tea.Cmd {
+	return func() tea.Msg {
+		queueInfos, err := pc.queueService.List(context.Background())
+		if err != nil {
+			return events.Error(err)
+		}
+
+		return PromptForQueueMsg{
+			Queues: queueInfos,
+			OnSelected: func(queueURL string) tea.Cmd {
+				return pc.SelectQueue(queueURL)
+			},
+		}
+	}
+}
+
+// SelectQueue starts polling the queue, which may be given either as a name or URL.  Polling of the previous queue
+// is stopped.  Selecting the queue being peeked at peeks at it again.  In consume mode, confirmation is required
+// before polling starts, as messages are deleted from the queue as they are received.
+func (pc *PollController) SelectQueue(queue string) tea.Cmd {
+	if pc.pollService.Mode() != models.PollModeConsume {
+		return pc.startPolling(queue)
+	}
+
+	return events.Confirm("consume and delete messages from "+models.QueueName(queue)+"? ", func() tea.Cmd {
+		return pc.startPolling(queue)
+	})
+}
+
+func (pc *PollController) startPolling(queue string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		queueURL, err := pc.queueService.QueueURL(ctx, queue)
+		if err != nil {
+			return events.Error(err)
+		}
+
+		pc.pollService.Start(ctx, queueURL)
+		return QueueSelected{Queue: queueURL}
+	}
+}
+
+// DeleteMessage deletes a message received in peek mode from the queue.
+func (pc *PollController) DeleteMessage(message models.Message) uimodels.Operation {
+	return uimodels.OperationFn(func(ctx context.Context) error {
+		uiCtx := uimodels.Ctx(ctx)
+
+		if err := pc.pollService.Delete(ctx, message); err != nil {
+			return err
+		}
+
+		uiCtx.Send(MessageDeleted{Message: message})
+		uiCtx.Message("Message " + message.ExtID + " deleted from queue")
+		return nil
+	})
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/services/messages"
+	"github.com/lmika/audax/internal/sqs-browse/services/pollmessage"
+	"github.com/pkg/errors"
+)
+
+type RedriveController struct {
+	messageService *messages.Service
+	pollService    *pollmessage.Service
+}
+
+func NewRedriveController(messageService *messages.Service, pollService *pollmessage.Service) *RedriveController {
+	return &RedriveController{
+		messageService: messageService,
+		pollService:    pollService,
+	}
+}
+
+// Redrive sends the messages to the destination queue.  If the destination queue is empty, the messages are
+// sent to the queue with a redrive policy targeting the polled queue.  Messages which were sent successfully are
+// deleted from the polled queue if they were received in peek mode.
+func (rc *RedriveController) Redrive(msgs []models.Message, destQueue string) tea.Cmd {
+	if len(msgs) == 0 {
+		return events.SetError(errors.New("no messages marked"))
+	}
+
+	return func() tea.Msg {
+		if destQueue == "" {
+			redriveQueue, err := rc.messageService.RedriveQueue(context.Background(), rc.pollService.Queue())
+			if err != nil {
+				return events.Error(err)
+			}
+			destQueue = redriveQueue
+		}
+
+		prompt := fmt.Sprintf("redrive %d messages to %v? ", len(msgs), models.QueueName(destQueue))
+		return events.Confirm(prompt, func() tea.Cmd {
+			return func() tea.Msg {
+				return rc.redrive(context.Background(), msgs, destQueue)
+			}
+		})()
+	}
+}
+
+func (rc *RedriveController) redrive(ctx context.Context, msgs []models.Message, destQueue string) tea.Msg {
+	result := MessagesRedriven{Queue: destQueue}
+
+	sendErrs, err := rc.messageService.SendBatch(ctx, msgs, destQueue, models.SendOptions{})
+	if err != nil {
+		return events.Error(err)
+	}
+	for i, msg := range msgs {
+		if sendErrs[i] != nil {
+			log.Printf("cannot redrive message %v: %v", msg.ExtID, sendErrs[i])
+			result.Failures = append(result.Failures, RedriveFailure{Message: msg, Err: sendErrs[i]})
+			continue
+		}
+
+		// Messages received in consume mode have already been deleted
+		if rc.pollService.Mode() == models.PollModePeek {
+			if err := rc.pollService.Delete(ctx, msg); err != nil {
+				log.Printf("redrove message %v but cannot delete original: %v", msg.ExtID, err)
+				result.Failures = append(result.Failures, RedriveFailure{
+					Message: msg,
+					Err:     errors.Wrap(err, "sent but original not deleted"),
+				})
+				continue
+			}
+		}
+
+		result.Redriven = append(result.Redriven, msg)
+	}
+	return result
+}
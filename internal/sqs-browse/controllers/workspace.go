@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/models/filterexpr"
+	"github.com/lmika/audax/internal/sqs-browse/services/workspace"
+)
+
+type WorkspaceController struct {
+	workspaceService *workspace.Service
+}
+
+func NewWorkspaceController(workspaceService *workspace.Service) *WorkspaceController {
+	return &WorkspaceController{
+		workspaceService: workspaceService,
+	}
+}
+
+// LoadMessages loads the messages previously captured in the workspace.
+func (wc *WorkspaceController) LoadMessages() tea.Cmd {
+	return func() tea.Msg {
+		msgs, err := wc.workspaceService.List(context.Background())
+		if err != nil {
+			return events.Error(err)
+		}
+		return WorkspaceLoaded{Messages: msgs}
+	}
+}
+
+// Filter shows the messages in the workspace matching the filter expression.  New messages will only be shown
+// if they match the filter.  An empty expression clears the filter.
+func (wc *WorkspaceController) Filter(expr string) tea.Cmd {
+	var filter *filterexpr.FilterExpr
+	if expr != "" {
+		var err error
+		if filter, err = filterexpr.Parse(expr); err != nil {
+			return events.SetError(err)
+		}
+	}
+
+	return func() tea.Msg {
+		msgs, err := wc.workspaceService.Filter(context.Background(), filter)
+		if err != nil {
+			return events.Error(err)
+		}
+		return WorkspaceFiltered{Filter: filter, Messages: msgs}
+	}
+}
+
+func (wc *WorkspaceController) Tag(message models.Message, tags []string) tea.Cmd {
+	return func() tea.Msg {
+		updatedMessage, err := wc.workspaceService.Tag(context.Background(), message, tags)
+		if err != nil {
+			return events.Error(err)
+		}
+		return MessageUpdated{Message: updatedMessage}
+	}
+}
+
+func (wc *WorkspaceController) Untag(message models.Message, tags []string) tea.Cmd {
+	return func() tea.Msg {
+		updatedMessage, err := wc.workspaceService.Untag(context.Background(), message, tags)
+		if err != nil {
+			return events.Error(err)
+		}
+		return MessageUpdated{Message: updatedMessage}
+	}
+}
+
+func (wc *WorkspaceController) Annotate(message models.Message, note string) tea.Cmd {
+	return func() tea.Msg {
+		updatedMessage, err := wc.workspaceService.Annotate(context.Background(), message, note)
+		if err != nil {
+			return events.Error(err)
+		}
+		return MessageUpdated{Message: updatedMessage}
+	}
+}
+
+// DeleteMessage removes the message from the workspace after confirmation.  The message remains on the queue.
+func (wc *WorkspaceController) DeleteMessage(message models.Message) tea.Cmd {
+	return events.Confirm(fmt.Sprintf("remove message %v from workspace? ", message.ID), func() tea.Cmd {
+		return func() tea.Msg {
+			if err := wc.workspaceService.Delete(context.Background(), message); err != nil {
+				return events.Error(err)
+			}
+			return MessageRemoved{Message: message}
+		}
+	})
+}
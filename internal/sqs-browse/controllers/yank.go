@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/lmika/audax/internal/common/clipboard"
+	"github.com/lmika/audax/internal/common/ui/uimodels"
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
+
+// YankMessageBody copies the body of the message to the clipboard.
+func YankMessageBody(message models.Message) uimodels.Operation {
+	return uimodels.OperationFn(func(ctx context.Context) error {
+		if err := clipboard.Write(message.Data); err != nil {
+			return err
+		}
+
+		uimodels.Ctx(ctx).Message("Message body copied to clipboard")
+		return nil
+	})
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const editHeader = `# Edit the message attributes and body.  Save and exit to send the message.
+# Attributes are written one per line as "name (DataType): value", with binary values encoded
+# as base64.  A blank line separates the attributes from the body.
+`
+
+var editAttributeLine = regexp.MustCompile(`^(.+?) \(([^)]+)\): ?(.*)$`)
+
+// FormatForEdit returns the message attributes and body in the format used when editing the message.
+func FormatForEdit(msg Message) string {
+	sb := new(strings.Builder)
+	sb.WriteString(editHeader)
+	for _, name := range msg.AttributeNames() {
+		attr := msg.Attributes[name]
+		value := attr.StringValue
+		if attr.IsBinary() {
+			value = base64.StdEncoding.EncodeToString(attr.BinaryValue)
+		}
+		sb.WriteString(name + " (" + attr.DataType + "): " + value + "\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(msg.Data)
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// ParseEdited returns a copy of the original message with the attributes and body parsed from the edited text,
+// which is in the format returned by FormatForEdit.
+func ParseEdited(original Message, text string) (Message, error) {
+	lines := strings.Split(text, "\n")
+	for len(lines) > 0 && strings.HasPrefix(lines[0], "#") {
+		lines = lines[1:]
+	}
+
+	attrs := make(map[string]MessageAttribute)
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) != "" {
+		parts := editAttributeLine.FindStringSubmatch(lines[0])
+		if parts == nil {
+			return Message{}, errors.Errorf("invalid attribute line: %v", lines[0])
+		}
+
+		attr := MessageAttribute{DataType: parts[2]}
+		if attr.IsBinary() {
+			bts, err := base64.StdEncoding.DecodeString(parts[3])
+			if err != nil {
+				return Message{}, errors.Wrapf(err, "invalid binary value of attribute %v", parts[1])
+			}
+			attr.BinaryValue = bts
+		} else {
+			attr.StringValue = parts[3]
+		}
+		attrs[parts[1]] = attr
+		lines = lines[1:]
+	}
+	if len(lines) > 0 {
+		lines = lines[1:]
+	}
+
+	edited := original
+	edited.Attributes = nil
+	if len(attrs) > 0 {
+		edited.Attributes = attrs
+	}
+	edited.Data = strings.TrimRight(strings.Join(lines, "\n"), " \t\r\n")
+	if err := ValidateEditedBody(original, edited.Data); err != nil {
+		return Message{}, err
+	}
+	return edited, nil
+}
+
+// ValidateEditedBody checks the edited body of a message.  If the original body is JSON, the edited body must
+// also be valid JSON.
+func ValidateEditedBody(original Message, body string) error {
+	if strings.TrimSpace(body) == "" {
+		return errors.New("message body is empty")
+	}
+	if !json.Valid([]byte(original.Data)) {
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return errors.Wrap(err, "message body is not valid JSON")
+	}
+	return nil
+}
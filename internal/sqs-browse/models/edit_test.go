@@ -0,0 +1,53 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEdited(t *testing.T) {
+	original := models.Message{
+		ExtID: "abc-123",
+		Data:  `{"type": "OrderPlaced"}`,
+		Attributes: map[string]models.MessageAttribute{
+			"source":  {DataType: "String", StringValue: "checkout"},
+			"payload": {DataType: "Binary", BinaryValue: []byte("hello")},
+		},
+	}
+
+	t.Run("should return the message unchanged if not edited", func(t *testing.T) {
+		edited, err := models.ParseEdited(original, models.FormatForEdit(original))
+		assert.NoError(t, err)
+		assert.Equal(t, original, edited)
+	})
+
+	t.Run("should parse edited attributes and body", func(t *testing.T) {
+		text := "# comment\nsource (String): retry\nattempt (Number): 2\n\n{\n  \"type\": \"OrderShipped\"\n}\n\n"
+
+		edited, err := models.ParseEdited(original, text)
+		assert.NoError(t, err)
+		assert.Equal(t, "abc-123", edited.ExtID)
+		assert.Equal(t, "{\n  \"type\": \"OrderShipped\"\n}", edited.Data)
+		assert.Equal(t, map[string]models.MessageAttribute{
+			"source":  {DataType: "String", StringValue: "retry"},
+			"attempt": {DataType: "Number", StringValue: "2"},
+		}, edited.Attributes)
+	})
+
+	t.Run("should return error if JSON body is no longer valid", func(t *testing.T) {
+		_, err := models.ParseEdited(original, "\n{\"type\": \n")
+		assert.Error(t, err)
+	})
+
+	t.Run("should allow any body if the original is not JSON", func(t *testing.T) {
+		_, err := models.ParseEdited(models.Message{Data: "plain text"}, "\n{not json\n")
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error if attribute line is malformed", func(t *testing.T) {
+		_, err := models.ParseEdited(original, "source = retry\n\n{}\n")
+		assert.Error(t, err)
+	})
+}
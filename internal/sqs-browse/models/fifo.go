@@ -0,0 +1,70 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultMessageGroupID is the group ID of messages sent to FIFO queues without a group ID.
+const DefaultMessageGroupID = "default"
+
+// IsFIFOQueue returns true if the queue, given either as a name or URL, is a FIFO queue.
+func IsFIFOQueue(queue string) bool {
+	return strings.HasSuffix(queue, ".fifo")
+}
+
+// DeduplicationStrategy determines the deduplication ID of messages sent to FIFO queues.
+type DeduplicationStrategy string
+
+const (
+	// DeduplicationContentHash uses the SHA-256 hash of the message body, similar to content-based deduplication.
+	DeduplicationContentHash DeduplicationStrategy = "content"
+
+	// DeduplicationOriginal uses the deduplication ID of the original message, or the original message ID if the
+	// message was received from a standard queue.
+	DeduplicationOriginal DeduplicationStrategy = "original"
+
+	// DeduplicationRandom uses a random ID, so that messages are never treated as duplicates.
+	DeduplicationRandom DeduplicationStrategy = "random"
+)
+
+func ParseDeduplicationStrategy(s string) (DeduplicationStrategy, error) {
+	switch ds := DeduplicationStrategy(s); ds {
+	case DeduplicationContentHash, DeduplicationOriginal, DeduplicationRandom:
+		return ds, nil
+	}
+	return "", errors.Errorf("unrecognised deduplication strategy '%v': expected content, original or random", s)
+}
+
+// DeduplicationID returns the deduplication ID of the message.
+func (ds DeduplicationStrategy) DeduplicationID(msg Message) string {
+	switch ds {
+	case DeduplicationOriginal:
+		if msg.MessageDeduplicationID != "" {
+			return msg.MessageDeduplicationID
+		} else if msg.ExtID != "" {
+			return msg.ExtID
+		}
+	case DeduplicationRandom:
+		var bts [16]byte
+		if _, err := rand.Read(bts[:]); err == nil {
+			return hex.EncodeToString(bts[:])
+		}
+	}
+
+	hash := sha256.Sum256([]byte(msg.Data))
+	return hex.EncodeToString(hash[:])
+}
+
+// FIFOOptions are the options used when sending messages to FIFO queues.
+type FIFOOptions struct {
+	// GroupID is the group ID of messages without one
+	GroupID string
+
+	// Deduplication determines the deduplication ID of messages
+	Deduplication DeduplicationStrategy
+}
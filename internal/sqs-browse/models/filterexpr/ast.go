@@ -0,0 +1,123 @@
+package filterexpr
+
+import (
+	"regexp"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+	"github.com/pkg/errors"
+)
+
+type astExpr struct {
+	Or *astDisjunction `parser:"@@"`
+}
+
+type astDisjunction struct {
+	Operands []*astConjunction `parser:"@@ ( ( 'or' | '||' ) @@ )*"`
+}
+
+type astConjunction struct {
+	Operands []*astNegation `parser:"@@ ( ( 'and' | '&&' ) @@ )*"`
+}
+
+type astNegation struct {
+	Not     bool           `parser:"@( 'not' | '!' )?"`
+	Operand *astComparison `parser:"@@"`
+}
+
+type astComparison struct {
+	Left  *astOperand `parser:"@@"`
+	Op    string      `parser:"( @( '==' | '!=' | '<=' | '>=' | '<' | '>' | '^=' | '=~' )"`
+	Right *astOperand `parser:"@@ )?"`
+
+	// re is the compiled regular expression of the right operand of '=~'
+	re *regexp.Regexp
+}
+
+type astOperand struct {
+	Path      *astPath      `parser:"  @@"`
+	Attribute *astAttribute `parser:"| @@"`
+	StringVal *string       `parser:"| @String"`
+	Number    *float64      `parser:"| @Number"`
+	Keyword   string        `parser:"| @( 'true' | 'false' | 'null' )"`
+	Sub       *astExpr      `parser:"| '(' @@ ')'"`
+}
+
+type astPath struct {
+	Segments []*astPathSegment `parser:"'$' @@*"`
+}
+
+type astPathSegment struct {
+	Field *string   `parser:"  '.' @Ident"`
+	Index *astIndex `parser:"| '[' @@ ']'"`
+}
+
+type astIndex struct {
+	Position *int    `parser:"  @Number"`
+	Key      *string `parser:"| @String"`
+}
+
+type astAttribute struct {
+	Name string `parser:"'@' @( Ident | String )"`
+}
+
+var filterLexer = lexer.MustSimple([]lexer.Rule{
+	{Name: "whitespace", Pattern: `\s+`},
+	{Name: "String", Pattern: `"(\\.|[^"\\])*"`},
+	{Name: "Number", Pattern: `-?\d+(\.\d+)?`},
+	{Name: "Ident", Pattern: `[A-Za-z_][A-Za-z0-9_-]*`},
+	{Name: "Operator", Pattern: `==|!=|<=|>=|\^=|=~|&&|\|\||[<>!$@.\[\]()]`},
+})
+
+var parser = participle.MustBuild(&astExpr{},
+	participle.Lexer(filterLexer),
+	participle.Unquote("String"),
+)
+
+func Parse(expr string) (*FilterExpr, error) {
+	var ast astExpr
+
+	if err := parser.ParseString("expr", expr, &ast); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse expression: '%v'", expr)
+	}
+	if err := ast.prepare(); err != nil {
+		return nil, errors.Wrapf(err, "invalid expression: '%v'", expr)
+	}
+
+	return &FilterExpr{ast: &ast}, nil
+}
+
+// prepare validates the expression and compiles the regular expressions it contains.
+func (a *astExpr) prepare() error {
+	for _, conj := range a.Or.Operands {
+		for _, neg := range conj.Operands {
+			if err := neg.Operand.prepare(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *astComparison) prepare() error {
+	for _, operand := range []*astOperand{a.Left, a.Right} {
+		if operand != nil && operand.Sub != nil {
+			if err := operand.Sub.prepare(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if a.Op != "=~" {
+		return nil
+	} else if a.Right.StringVal == nil {
+		return errors.New("right side of '=~' must be a regular expression string")
+	}
+
+	re, err := regexp.Compile(*a.Right.StringVal)
+	if err != nil {
+		return errors.Wrap(err, "invalid regular expression")
+	}
+	a.re = re
+	return nil
+}
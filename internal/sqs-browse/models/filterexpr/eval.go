@@ -0,0 +1,244 @@
+package filterexpr
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
+
+// evalContext is the message the expression is evaluated against.  The body is decoded when first needed.
+type evalContext struct {
+	msg        models.Message
+	body       any
+	bodyIsJSON bool
+	decoded    bool
+}
+
+func newEvalContext(msg models.Message) *evalContext {
+	return &evalContext{msg: msg}
+}
+
+func (ec *evalContext) jsonBody() (any, bool) {
+	if !ec.decoded {
+		ec.bodyIsJSON = json.Unmarshal([]byte(ec.msg.Data), &ec.body) == nil
+		ec.decoded = true
+	}
+	return ec.body, ec.bodyIsJSON
+}
+
+func (a *astExpr) evalBool(ec *evalContext) bool {
+	for _, conj := range a.Or.Operands {
+		if conj.evalBool(ec) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *astConjunction) evalBool(ec *evalContext) bool {
+	for _, neg := range a.Operands {
+		if !neg.evalBool(ec) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *astNegation) evalBool(ec *evalContext) bool {
+	return a.Operand.evalBool(ec) != a.Not
+}
+
+func (a *astComparison) evalBool(ec *evalContext) bool {
+	left, leftOk := a.Left.eval(ec)
+	if a.Op == "" {
+		return leftOk && isTruthy(left)
+	}
+
+	right, rightOk := a.Right.eval(ec)
+	switch a.Op {
+	case "==":
+		return leftOk && rightOk && isEqual(left, right)
+	case "!=":
+		return !(leftOk && rightOk && isEqual(left, right))
+	case "^=":
+		leftStr, isLeftStr := left.(string)
+		rightStr, isRightStr := right.(string)
+		return leftOk && isLeftStr && isRightStr && strings.HasPrefix(leftStr, rightStr)
+	case "=~":
+		leftStr, isLeftStr := toString(left)
+		return leftOk && isLeftStr && a.re.MatchString(leftStr)
+	}
+
+	if !leftOk || !rightOk {
+		return false
+	}
+	cmp, comparable := compare(left, right)
+	if !comparable {
+		return false
+	}
+	switch a.Op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// eval returns the value of the operand.  The boolean is false if the value is not present.
+func (a *astOperand) eval(ec *evalContext) (any, bool) {
+	switch {
+	case a.Path != nil:
+		return a.Path.eval(ec)
+	case a.Attribute != nil:
+		return a.Attribute.eval(ec)
+	case a.StringVal != nil:
+		return *a.StringVal, true
+	case a.Number != nil:
+		return *a.Number, true
+	case a.Sub != nil:
+		return a.Sub.evalBool(ec), true
+	}
+
+	switch a.Keyword {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	}
+	return nil, true
+}
+
+func (a *astPath) eval(ec *evalContext) (any, bool) {
+	value, ok := ec.jsonBody()
+	if !ok {
+		return nil, false
+	}
+
+	for _, seg := range a.Segments {
+		switch {
+		case seg.Field != nil:
+			value, ok = lookupKey(value, *seg.Field)
+		case seg.Index.Key != nil:
+			value, ok = lookupKey(value, *seg.Index.Key)
+		default:
+			value, ok = lookupIndex(value, *seg.Index.Position)
+		}
+		if !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func (a *astAttribute) eval(ec *evalContext) (any, bool) {
+	attr, hasAttr := ec.msg.Attributes[a.Name]
+	if !hasAttr || attr.IsBinary() {
+		return nil, false
+	}
+
+	if strings.HasPrefix(attr.DataType, "Number") {
+		if f, err := strconv.ParseFloat(attr.StringValue, 64); err == nil {
+			return f, true
+		}
+	}
+	return attr.StringValue, true
+}
+
+func lookupKey(value any, key string) (any, bool) {
+	obj, isObj := value.(map[string]any)
+	if !isObj {
+		return nil, false
+	}
+	v, hasKey := obj[key]
+	return v, hasKey
+}
+
+func lookupIndex(value any, idx int) (any, bool) {
+	arr, isArr := value.([]any)
+	if !isArr {
+		return nil, false
+	}
+	if idx < 0 {
+		idx += len(arr)
+	}
+	if idx < 0 || idx >= len(arr) {
+		return nil, false
+	}
+	return arr[idx], true
+}
+
+func isTruthy(value any) bool {
+	return value != nil && value != false
+}
+
+// isEqual returns true if the two values are equal.  Strings holding numbers are equal to the number they
+// represent, which allows comparing values of string attributes against numbers.
+func isEqual(left, right any) bool {
+	if leftF, rightF, ok := asNumbers(left, right); ok {
+		return leftF == rightF
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+// compare returns the ordering of two numbers or two strings.  The boolean is false if the values cannot be
+// compared.
+func compare(left, right any) (int, bool) {
+	if leftF, rightF, ok := asNumbers(left, right); ok {
+		switch {
+		case leftF < rightF:
+			return -1, true
+		case leftF > rightF:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	leftStr, isLeftStr := left.(string)
+	rightStr, isRightStr := right.(string)
+	if !isLeftStr || !isRightStr {
+		return 0, false
+	}
+	return strings.Compare(leftStr, rightStr), true
+}
+
+// asNumbers returns the values as numbers if at least one is a number and the other is a number or a string
+// holding a number.
+func asNumbers(left, right any) (float64, float64, bool) {
+	leftF, isLeftNum := left.(float64)
+	rightF, isRightNum := right.(float64)
+	switch {
+	case isLeftNum && isRightNum:
+		return leftF, rightF, true
+	case isLeftNum:
+		if rightStr, isStr := right.(string); isStr {
+			f, err := strconv.ParseFloat(rightStr, 64)
+			return leftF, f, err == nil
+		}
+	case isRightNum:
+		if leftStr, isStr := left.(string); isStr {
+			f, err := strconv.ParseFloat(leftStr, 64)
+			return f, rightF, err == nil
+		}
+	}
+	return 0, 0, false
+}
+
+func toString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
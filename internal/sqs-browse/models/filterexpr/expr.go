@@ -0,0 +1,22 @@
+package filterexpr
+
+import (
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
+
+// FilterExpr is a filter expression over the JSON body and attributes of a message, such as
+// `$.detail.type == "OrderPlaced" and @source ^= "billing"`.  Paths starting with '$' select values from the
+// body while names starting with '@' select message attributes.
+type FilterExpr struct {
+	ast *astExpr
+}
+
+// Matches returns true if the message matches the filter.  Messages with bodies that are not JSON will only
+// match filters that do not depend on the body.
+func (fe *FilterExpr) Matches(msg models.Message) bool {
+	return fe.ast.evalBool(newEvalContext(msg))
+}
+
+func (fe *FilterExpr) String() string {
+	return fe.ast.String()
+}
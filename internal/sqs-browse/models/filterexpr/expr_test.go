@@ -0,0 +1,97 @@
+package filterexpr_test
+
+import (
+	"testing"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/models/filterexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterExpr_Matches(t *testing.T) {
+	msg := models.Message{
+		Data: `{"detail-type":"OrderPlaced","detail":{"type":"OrderPlaced","total":42.5,"items":[{"sku":"abc-123"},{"sku":"def-456"}],"express":true,"coupon":null}}`,
+		Attributes: map[string]models.MessageAttribute{
+			"source":   {DataType: "String", StringValue: "billing-service"},
+			"retries":  {DataType: "Number", StringValue: "3"},
+			"trace id": {DataType: "String", StringValue: "t-1"},
+		},
+	}
+
+	scenarios := []struct {
+		expr    string
+		matches bool
+	}{
+		{expr: `$.detail.type == "OrderPlaced"`, matches: true},
+		{expr: `$.detail.type == "OrderCancelled"`, matches: false},
+		{expr: `$.detail.type != "OrderCancelled"`, matches: true},
+		{expr: `$["detail-type"] == "OrderPlaced"`, matches: true},
+		{expr: `$.detail-type == "OrderPlaced"`, matches: true},
+		{expr: `$.detail.total > 40`, matches: true},
+		{expr: `$.detail.total <= 40`, matches: false},
+		{expr: `$.detail.items[1].sku == "def-456"`, matches: true},
+		{expr: `$.detail.items[-1].sku ^= "def"`, matches: true},
+		{expr: `$.detail.items[2].sku == "def-456"`, matches: false},
+		{expr: `$.detail.items[0].sku =~ "^[a-z]+-\\d+$"`, matches: true},
+		{expr: `$.detail.express`, matches: true},
+		{expr: `$.detail.coupon`, matches: false},
+		{expr: `$.detail.missing`, matches: false},
+		{expr: `not $.detail.missing`, matches: true},
+		{expr: `$.detail.coupon == null`, matches: true},
+		{expr: `@source ^= "billing"`, matches: true},
+		{expr: `@retries >= 3`, matches: true},
+		{expr: `@retries == "3"`, matches: true},
+		{expr: `@"trace id" == "t-1"`, matches: true},
+		{expr: `@missing`, matches: false},
+		{expr: `$.detail.type == "OrderPlaced" and @retries > 5`, matches: false},
+		{expr: `$.detail.type == "OrderPlaced" && (@retries > 5 || @source =~ "billing")`, matches: true},
+		{expr: `$.detail.type == "OrderCancelled" or !$.detail.express`, matches: false},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.expr, func(t *testing.T) {
+			expr, err := filterexpr.Parse(scenario.expr)
+			assert.NoError(t, err)
+
+			assert.Equal(t, scenario.matches, expr.Matches(msg))
+		})
+	}
+
+	t.Run("should only match non-JSON bodies with filters not using the body", func(t *testing.T) {
+		msg := models.Message{
+			Data: "not json",
+			Attributes: map[string]models.MessageAttribute{
+				"source": {DataType: "String", StringValue: "billing-service"},
+			},
+		}
+
+		expr, err := filterexpr.Parse(`$.detail.type == "OrderPlaced"`)
+		assert.NoError(t, err)
+		assert.False(t, expr.Matches(msg))
+
+		expr, err = filterexpr.Parse(`@source == "billing-service"`)
+		assert.NoError(t, err)
+		assert.True(t, expr.Matches(msg))
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should return normalised expression", func(t *testing.T) {
+		expr, err := filterexpr.Parse(`$.detail["detail-type"]=="a"&&!(@x>1||@"trace id")`)
+		assert.NoError(t, err)
+		assert.Equal(t, `$.detail["detail-type"] == "a" and not (@x > 1 or @"trace id")`, expr.String())
+	})
+
+	t.Run("should return error for invalid expressions", func(t *testing.T) {
+		for _, badExpr := range []string{
+			``,
+			`$.detail.type ==`,
+			`detail.type == "a"`,
+			`$.detail.type =~ 123`,
+			`$.detail.type =~ "("`,
+		} {
+			_, err := filterexpr.Parse(badExpr)
+			assert.Error(t, err, badExpr)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package filterexpr
+
+import (
+	"strconv"
+	"strings"
+)
+
+func (a *astExpr) String() string {
+	return a.Or.String()
+}
+
+func (a *astDisjunction) String() string {
+	operands := make([]string, len(a.Operands))
+	for i, conj := range a.Operands {
+		operands[i] = conj.String()
+	}
+	return strings.Join(operands, " or ")
+}
+
+func (a *astConjunction) String() string {
+	operands := make([]string, len(a.Operands))
+	for i, neg := range a.Operands {
+		operands[i] = neg.String()
+	}
+	return strings.Join(operands, " and ")
+}
+
+func (a *astNegation) String() string {
+	if a.Not {
+		return "not " + a.Operand.String()
+	}
+	return a.Operand.String()
+}
+
+func (a *astComparison) String() string {
+	if a.Op == "" {
+		return a.Left.String()
+	}
+	return a.Left.String() + " " + a.Op + " " + a.Right.String()
+}
+
+func (a *astOperand) String() string {
+	switch {
+	case a.Path != nil:
+		return a.Path.String()
+	case a.Attribute != nil:
+		return a.Attribute.String()
+	case a.StringVal != nil:
+		return strconv.Quote(*a.StringVal)
+	case a.Number != nil:
+		return strconv.FormatFloat(*a.Number, 'f', -1, 64)
+	case a.Sub != nil:
+		return "(" + a.Sub.String() + ")"
+	}
+	return a.Keyword
+}
+
+func (a *astPath) String() string {
+	sb := new(strings.Builder)
+	sb.WriteString("$")
+	for _, seg := range a.Segments {
+		switch {
+		case seg.Field != nil:
+			sb.WriteString("." + *seg.Field)
+		case seg.Index.Key != nil:
+			sb.WriteString("[" + strconv.Quote(*seg.Index.Key) + "]")
+		default:
+			sb.WriteString("[" + strconv.Itoa(*seg.Index.Position) + "]")
+		}
+	}
+	return sb.String()
+}
+
+func (a *astAttribute) String() string {
+	if isIdent(a.Name) {
+		return "@" + a.Name
+	}
+	return "@" + strconv.Quote(a.Name)
+}
+
+func isIdent(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r == '-' || r >= '0' && r <= '9'):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
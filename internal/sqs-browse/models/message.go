@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"sort"
+	"strings"
+	"time"
+)
 
 type Message struct {
 	ID       uint64 `storm:"id,increment"`
@@ -8,4 +12,79 @@ type Message struct {
 	Queue    string `storm:"index"`
 	Received time.Time
 	Data     string
+
+	// Attributes are the message attributes set by the sender
+	Attributes map[string]MessageAttribute
+
+	// System metadata of the message
+	SentTimestamp           time.Time
+	ApproximateReceiveCount int
+	MessageGroupID          string
+	MessageDeduplicationID  string
+	SenderID                string
+
+	// Tags and Note are set by the user to keep track of messages within a workspace
+	Tags []string
+	Note string
+
+	// ReceiptHandle is the handle of the most recent receive of the message.  It is only valid for the
+	// current session so it is not persisted.
+	ReceiptHandle string `json:"-"`
+}
+
+// AttributeNames returns the names of the message attributes in sorted order.
+func (m Message) AttributeNames() []string {
+	names := make([]string, 0, len(m.Attributes))
+	for name := range m.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasTag returns true if the message has the tag.
+func (m Message) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns true if the query appears, ignoring case, within the message body, ID, tags, note or
+// attribute values.
+func (m Message) Matches(query string) bool {
+	query = strings.ToLower(query)
+	contains := func(s string) bool {
+		return strings.Contains(strings.ToLower(s), query)
+	}
+
+	if contains(m.Data) || contains(m.ExtID) || contains(m.Note) {
+		return true
+	}
+	for _, tag := range m.Tags {
+		if contains(tag) {
+			return true
+		}
+	}
+	for _, attr := range m.Attributes {
+		if !attr.IsBinary() && contains(attr.StringValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// MessageAttribute is a message attribute set by the sender.  String and Number attributes use StringValue
+// while Binary attributes use BinaryValue.
+type MessageAttribute struct {
+	DataType    string
+	StringValue string
+	BinaryValue []byte
+}
+
+// IsBinary returns true if the attribute holds a binary value.
+func (ma MessageAttribute) IsBinary() bool {
+	return strings.HasPrefix(ma.DataType, "Binary")
 }
@@ -0,0 +1,40 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_Matches(t *testing.T) {
+	msg := models.Message{
+		ExtID: "abc-123",
+		Data:  `{"type": "OrderPlaced"}`,
+		Tags:  []string{"retry"},
+		Note:  "Customer complaint",
+		Attributes: map[string]models.MessageAttribute{
+			"source":  {DataType: "String", StringValue: "checkout"},
+			"payload": {DataType: "Binary", BinaryValue: []byte("hidden")},
+		},
+	}
+
+	scenarios := []struct {
+		query string
+		want  bool
+	}{
+		{query: "orderplaced", want: true},
+		{query: "ABC-123", want: true},
+		{query: "retry", want: true},
+		{query: "complaint", want: true},
+		{query: "checkout", want: true},
+		{query: "hidden", want: false},
+		{query: "OrderShipped", want: false},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.query, func(t *testing.T) {
+			assert.Equal(t, scenario.want, msg.Matches(scenario.query))
+		})
+	}
+}
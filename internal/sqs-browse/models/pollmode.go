@@ -0,0 +1,23 @@
+package models
+
+// PollMode determines what happens to messages once they have been received from a queue.
+type PollMode int
+
+const (
+	// PollModeConsume deletes messages from the queue as soon as they are received.
+	PollModeConsume PollMode = iota
+
+	// PollModePeek receives each message once, releasing received messages back to the queue once polling
+	// stops, leaving them for other consumers unless they are explicitly deleted.
+	PollModePeek
+)
+
+func (pm PollMode) String() string {
+	switch pm {
+	case PollModeConsume:
+		return "consume"
+	case PollModePeek:
+		return "peek"
+	}
+	return "unknown"
+}
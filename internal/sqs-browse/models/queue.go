@@ -0,0 +1,28 @@
+package models
+
+import "strings"
+
+// QueueName returns the name of the queue from the queue URL.
+func QueueName(queueURL string) string {
+	if idx := strings.LastIndex(queueURL, "/"); idx >= 0 {
+		return queueURL[idx+1:]
+	}
+	return queueURL
+}
+
+// QueueInfo describes a queue and its attributes.
+type QueueInfo struct {
+	URL  string
+	Name string
+	ARN  string
+	FIFO bool
+
+	ApproximateMessages         int
+	ApproximateMessagesInFlight int
+
+	// DeadLetterQueue is the name of the dead-letter queue of this queue, if it has one
+	DeadLetterQueue string
+
+	// DeadLetterSources are the names of the queues which use this queue as their dead-letter queue
+	DeadLetterSources []string
+}
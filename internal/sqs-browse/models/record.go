@@ -0,0 +1,133 @@
+package models
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MessageRecord is the representation of a message used in JSONL files.
+type MessageRecord struct {
+	MessageID               string                     `json:"messageId,omitempty"`
+	Body                    string                     `json:"body"`
+	Attributes              map[string]RecordAttribute `json:"attributes,omitempty"`
+	SentTimestamp           *time.Time                 `json:"sentTimestamp,omitempty"`
+	ApproximateReceiveCount int                        `json:"approximateReceiveCount,omitempty"`
+	MessageGroupID          string                     `json:"messageGroupId,omitempty"`
+	MessageDeduplicationID  string                     `json:"messageDeduplicationId,omitempty"`
+	SenderID                string                     `json:"senderId,omitempty"`
+}
+
+// RecordAttribute is the representation of a message attribute used in JSONL files.
+type RecordAttribute struct {
+	DataType    string `json:"dataType"`
+	StringValue string `json:"stringValue,omitempty"`
+	BinaryValue []byte `json:"binaryValue,omitempty"`
+}
+
+func NewMessageRecord(msg Message) MessageRecord {
+	record := MessageRecord{
+		MessageID:               msg.ExtID,
+		Body:                    msg.Data,
+		ApproximateReceiveCount: msg.ApproximateReceiveCount,
+		MessageGroupID:          msg.MessageGroupID,
+		MessageDeduplicationID:  msg.MessageDeduplicationID,
+		SenderID:                msg.SenderID,
+	}
+	if !msg.SentTimestamp.IsZero() {
+		sentTimestamp := msg.SentTimestamp
+		record.SentTimestamp = &sentTimestamp
+	}
+	if len(msg.Attributes) > 0 {
+		record.Attributes = make(map[string]RecordAttribute, len(msg.Attributes))
+		for name, attr := range msg.Attributes {
+			record.Attributes[name] = RecordAttribute(attr)
+		}
+	}
+	return record
+}
+
+// Message returns the message of the record.
+func (r MessageRecord) Message() Message {
+	msg := Message{
+		ExtID:                   r.MessageID,
+		Data:                    r.Body,
+		ApproximateReceiveCount: r.ApproximateReceiveCount,
+		MessageGroupID:          r.MessageGroupID,
+		MessageDeduplicationID:  r.MessageDeduplicationID,
+		SenderID:                r.SenderID,
+	}
+	if r.SentTimestamp != nil {
+		msg.SentTimestamp = *r.SentTimestamp
+	}
+	if len(r.Attributes) > 0 {
+		msg.Attributes = make(map[string]MessageAttribute, len(r.Attributes))
+		for name, attr := range r.Attributes {
+			msg.Attributes[name] = MessageAttribute(attr)
+		}
+	}
+	return msg
+}
+
+// ParseMessageRecord parses a line of a JSONL file.  Lines which are objects with a "body" field are message records.
+// The body of a record may either be a string or a JSON value.  Any other line is used as the message body.
+func ParseMessageRecord(line []byte) (Message, error) {
+	line = bytes.TrimSpace(line)
+	if !json.Valid(line) {
+		return Message{}, errors.New("line is not valid JSON")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return Message{Data: string(line)}, nil
+	} else if _, hasBody := fields["body"]; !hasBody {
+		return Message{Data: string(line)}, nil
+	}
+
+	var record struct {
+		MessageRecord
+		Body json.RawMessage `json:"body"`
+	}
+	if err := json.Unmarshal(line, &record); err != nil {
+		return Message{}, errors.Wrap(err, "invalid message record")
+	}
+
+	// A body which is not a string is treated as JSON
+	if err := json.Unmarshal(record.Body, &record.MessageRecord.Body); err != nil {
+		record.MessageRecord.Body = string(record.Body)
+	}
+	return record.MessageRecord.Message(), nil
+}
+
+// ParseAttribute parses a message attribute of the form "name=value" or "name:DataType=value".  Attributes without
+// a data type are strings, and the values of binary attributes are base64 encoded.
+func ParseAttribute(s string) (string, MessageAttribute, error) {
+	nameAndType, value, hasValue := strings.Cut(s, "=")
+	if !hasValue {
+		return "", MessageAttribute{}, errors.Errorf("expected attribute of the form name=value: %v", s)
+	}
+
+	name, dataType, hasType := strings.Cut(nameAndType, ":")
+	if !hasType {
+		dataType = "String"
+	}
+	if name == "" {
+		return "", MessageAttribute{}, errors.Errorf("attribute name is empty: %v", s)
+	}
+
+	attr := MessageAttribute{DataType: dataType}
+	if attr.IsBinary() {
+		bts, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", MessageAttribute{}, errors.Wrapf(err, "invalid binary value of attribute %v", name)
+		}
+		attr.BinaryValue = bts
+	} else {
+		attr.StringValue = value
+	}
+	return name, attr, nil
+}
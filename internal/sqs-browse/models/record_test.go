@@ -0,0 +1,90 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMessageRecord(t *testing.T) {
+	scenarios := []struct {
+		desc string
+		line string
+		want models.Message
+	}{
+		{
+			desc: "message record with string body",
+			line: `{"body": "{\"type\":\"OrderPlaced\"}", "attributes": {"source": {"dataType": "String", "stringValue": "checkout"}}, "messageGroupId": "orders"}`,
+			want: models.Message{
+				Data:           `{"type":"OrderPlaced"}`,
+				Attributes:     map[string]models.MessageAttribute{"source": {DataType: "String", StringValue: "checkout"}},
+				MessageGroupID: "orders",
+			},
+		},
+		{
+			desc: "message record with JSON body",
+			line: `{"body": {"type": "OrderPlaced"}}`,
+			want: models.Message{Data: `{"type": "OrderPlaced"}`},
+		},
+		{
+			desc: "plain JSON object",
+			line: `{"type": "OrderPlaced"}`,
+			want: models.Message{Data: `{"type": "OrderPlaced"}`},
+		},
+		{
+			desc: "JSON string",
+			line: `"hello"`,
+			want: models.Message{Data: `"hello"`},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.desc, func(t *testing.T) {
+			msg, err := models.ParseMessageRecord([]byte(scenario.line))
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.want, msg)
+		})
+	}
+
+	t.Run("should return error if line is not JSON", func(t *testing.T) {
+		_, err := models.ParseMessageRecord([]byte(`{"body": `))
+		assert.Error(t, err)
+	})
+
+	t.Run("should round trip message records", func(t *testing.T) {
+		msg := models.Message{
+			ExtID:      "abc-123",
+			Data:       "plain text",
+			Attributes: map[string]models.MessageAttribute{"payload": {DataType: "Binary", BinaryValue: []byte{1, 2, 3}}},
+		}
+		assert.Equal(t, msg, models.NewMessageRecord(msg).Message())
+	})
+}
+
+func TestParseAttribute(t *testing.T) {
+	scenarios := []struct {
+		arg      string
+		wantName string
+		want     models.MessageAttribute
+	}{
+		{arg: "source=checkout", wantName: "source", want: models.MessageAttribute{DataType: "String", StringValue: "checkout"}},
+		{arg: "attempt:Number=2", wantName: "attempt", want: models.MessageAttribute{DataType: "Number", StringValue: "2"}},
+		{arg: "query=a=b", wantName: "query", want: models.MessageAttribute{DataType: "String", StringValue: "a=b"}},
+		{arg: "payload:Binary=AQID", wantName: "payload", want: models.MessageAttribute{DataType: "Binary", BinaryValue: []byte{1, 2, 3}}},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.arg, func(t *testing.T) {
+			name, attr, err := models.ParseAttribute(scenario.arg)
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.wantName, name)
+			assert.Equal(t, scenario.want, attr)
+		})
+	}
+
+	t.Run("should return error if value is missing", func(t *testing.T) {
+		_, _, err := models.ParseAttribute("source")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// MaxSendDelay is the longest delay SQS permits when sending a message.
+const MaxSendDelay = 15 * time.Minute
+
+// SendOptions are options used when sending messages.
+type SendOptions struct {
+	// Delay is how long messages remain invisible after being sent.  Delays cannot be set on messages sent to
+	// FIFO queues.
+	Delay time.Duration
+
+	// GroupID and DeduplicationID, if set, are used in place of the IDs of messages sent to FIFO queues.
+	GroupID         string
+	DeduplicationID string
+}
@@ -2,7 +2,10 @@ package sqs
 
 import (
 	"context"
+	"encoding/json"
 	"log"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -12,6 +15,20 @@ import (
 	"github.com/pkg/errors"
 )
 
+// peekVisibilityTimeout is the visibility timeout, in seconds, of messages received in peek mode.  Peeked
+// messages are held for this long, unless they are released earlier once polling stops.
+const peekVisibilityTimeout = 30
+
+// maxBatchSize is the maximum number of entries permitted in a single SQS batch request.
+const maxBatchSize = 10
+
+// maxBatchPayloadSize is the maximum total size, in bytes, of the messages in a single SQS batch request.
+const maxBatchPayloadSize = 256 * 1024
+
+// maxConcurrentAttributeRequests is the maximum number of queue attribute requests made at the same time when
+// listing queues.
+const maxConcurrentAttributeRequests = 10
+
 type Provider struct {
 	client *sqs.Client
 }
@@ -20,13 +37,17 @@ func NewProvider(client *sqs.Client) *Provider {
 	return &Provider{client: client}
 }
 
-func (p *Provider) SendMessage(ctx context.Context, msg models.Message, queue string) (string, error) {
-	// TEMP :: queue URL
+func (p *Provider) SendMessage(ctx context.Context, msg models.Message, queue string, opts models.SendOptions) (string, error) {
+	input := &sqs.SendMessageInput{
+		QueueUrl:          aws.String(queue),
+		MessageBody:       aws.String(msg.Data),
+		MessageAttributes: attributesToSQS(msg.Attributes),
+		DelaySeconds:      int32(opts.Delay / time.Second),
+	}
 
-	out, err := p.client.SendMessage(ctx, &sqs.SendMessageInput{
-		QueueUrl:    aws.String(queue),
-		MessageBody: aws.String(msg.Data),
-	})
+	input.MessageGroupId, input.MessageDeduplicationId = fifoIDs(msg)
+
+	out, err := p.client.SendMessage(ctx, input)
 	if err != nil {
 		return "", errors.Wrapf(err, "unable to send message to %v", queue)
 	}
@@ -34,12 +55,201 @@ func (p *Provider) SendMessage(ctx context.Context, msg models.Message, queue st
 	return aws.ToString(out.MessageId), nil
 }
 
-func (p *Provider) PollForNewMessages(ctx context.Context, queue string) ([]*models.Message, error) {
-	out, err := p.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
-		QueueUrl:            aws.String(queue),
-		MaxNumberOfMessages: 10,
-		WaitTimeSeconds:     20,
+// SendMessageBatch sends the messages to the queue in batches.  The returned slice holds the send error of each
+// message, which is nil if the message was sent successfully.
+func (p *Provider) SendMessageBatch(ctx context.Context, msgs []models.Message, queue string, opts models.SendOptions) []error {
+	sendErrs := make([]error, len(msgs))
+	for batchStart, batchEnd := 0, 0; batchStart < len(msgs); batchStart = batchEnd {
+		batchEnd = nextBatchEnd(msgs, batchStart)
+		batch := msgs[batchStart:batchEnd]
+
+		entries := make([]types.SendMessageBatchRequestEntry, len(batch))
+		for i, msg := range batch {
+			entries[i] = types.SendMessageBatchRequestEntry{
+				Id:                aws.String(strconv.Itoa(batchStart + i)),
+				MessageBody:       aws.String(msg.Data),
+				MessageAttributes: attributesToSQS(msg.Attributes),
+				DelaySeconds:      int32(opts.Delay / time.Second),
+			}
+			entries[i].MessageGroupId, entries[i].MessageDeduplicationId = fifoIDs(msg)
+		}
+
+		out, err := p.client.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+			QueueUrl: aws.String(queue),
+			Entries:  entries,
+		})
+		if err != nil {
+			for i := range batch {
+				sendErrs[batchStart+i] = errors.Wrapf(err, "unable to send message to %v", queue)
+			}
+			continue
+		}
+
+		for _, failed := range out.Failed {
+			if idx, err := strconv.Atoi(aws.ToString(failed.Id)); err == nil && idx < len(sendErrs) {
+				sendErrs[idx] = errors.Errorf("%v: %v", aws.ToString(failed.Code), aws.ToString(failed.Message))
+			}
+		}
+	}
+	return sendErrs
+}
+
+// DeadLetterSourceQueues returns the URLs of the queues with a redrive policy targeting the dead-letter queue.
+func (p *Provider) DeadLetterSourceQueues(ctx context.Context, dlq string) ([]string, error) {
+	dlqAttrs, err := p.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(dlq),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameQueueArn},
 	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get attributes of queue %v", dlq)
+	}
+	dlqArn := dlqAttrs.Attributes[string(types.QueueAttributeNameQueueArn)]
+
+	var candidates []string
+	paginator := sqs.NewListDeadLetterSourceQueuesPaginator(p.client, &sqs.ListDeadLetterSourceQueuesInput{
+		QueueUrl: aws.String(dlq),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to list source queues of %v", dlq)
+		}
+		candidates = append(candidates, out.QueueUrls...)
+	}
+
+	sourceQueues := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		out, err := p.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+			QueueUrl:       aws.String(candidate),
+			AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameRedrivePolicy},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get attributes of queue %v", candidate)
+		}
+
+		if deadLetterTargetArn(out.Attributes[string(types.QueueAttributeNameRedrivePolicy)]) == dlqArn {
+			sourceQueues = append(sourceQueues, candidate)
+		}
+	}
+	return sourceQueues, nil
+}
+
+// ListQueues returns the queues with their attributes.  The dead-letter relationships are only resolved between
+// the returned queues.  Queues whose attributes cannot be fetched are logged and left out.
+func (p *Provider) ListQueues(ctx context.Context) ([]models.QueueInfo, error) {
+	var queueURLs []string
+	paginator := sqs.NewListQueuesPaginator(p.client, &sqs.ListQueuesInput{})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to list queues")
+		}
+		queueURLs = append(queueURLs, out.QueueUrls...)
+	}
+
+	// Attributes are fetched concurrently.  Queues whose attributes cannot be fetched, such as those deleted since
+	// they were listed, are skipped.
+	queueInfos := make([]*models.QueueInfo, len(queueURLs))
+	targetArns := make([]string, len(queueURLs))
+
+	queueIdxs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < maxConcurrentAttributeRequests; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range queueIdxs {
+				queueInfo, targetArn, err := p.queueInfo(ctx, queueURLs[idx])
+				if err != nil {
+					log.Printf("warn: skipping queue: %v", err)
+					continue
+				}
+				queueInfos[idx], targetArns[idx] = queueInfo, targetArn
+			}
+		}()
+	}
+	for i := range queueURLs {
+		queueIdxs <- i
+	}
+	close(queueIdxs)
+	wg.Wait()
+
+	queues := make([]models.QueueInfo, 0, len(queueURLs))
+	deadLetterTargets := make(map[string]string)
+	for i, queueInfo := range queueInfos {
+		if queueInfo == nil {
+			continue
+		}
+		if targetArns[i] != "" {
+			deadLetterTargets[queueInfo.ARN] = targetArns[i]
+		}
+		queues = append(queues, *queueInfo)
+	}
+
+	queueIdxByArn := make(map[string]int, len(queues))
+	for i, queue := range queues {
+		queueIdxByArn[queue.ARN] = i
+	}
+	for i, queue := range queues {
+		targetIdx, hasTarget := queueIdxByArn[deadLetterTargets[queue.ARN]]
+		if !hasTarget {
+			continue
+		}
+		queues[i].DeadLetterQueue = queues[targetIdx].Name
+		queues[targetIdx].DeadLetterSources = append(queues[targetIdx].DeadLetterSources, queue.Name)
+	}
+	return queues, nil
+}
+
+// queueInfo returns the attributes of the queue, along with the ARN of its dead-letter queue if it has one.
+func (p *Provider) queueInfo(ctx context.Context, queueURL string) (*models.QueueInfo, string, error) {
+	out, err := p.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl: aws.String(queueURL),
+		AttributeNames: []types.QueueAttributeName{
+			types.QueueAttributeNameQueueArn,
+			types.QueueAttributeNameApproximateNumberOfMessages,
+			types.QueueAttributeNameApproximateNumberOfMessagesNotVisible,
+			types.QueueAttributeNameRedrivePolicy,
+			types.QueueAttributeNameFifoQueue,
+		},
+	})
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "unable to get attributes of queue %v", queueURL)
+	}
+
+	queue := &models.QueueInfo{
+		URL:  queueURL,
+		Name: models.QueueName(queueURL),
+		ARN:  out.Attributes[string(types.QueueAttributeNameQueueArn)],
+		FIFO: out.Attributes[string(types.QueueAttributeNameFifoQueue)] == "true",
+	}
+	queue.ApproximateMessages, _ = strconv.Atoi(out.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)])
+	queue.ApproximateMessagesInFlight, _ = strconv.Atoi(out.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessagesNotVisible)])
+	return queue, deadLetterTargetArn(out.Attributes[string(types.QueueAttributeNameRedrivePolicy)]), nil
+}
+
+// QueueURL returns the URL of the queue with the given name.
+func (p *Provider) QueueURL(ctx context.Context, queueName string) (string, error) {
+	out, err := p.client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{QueueName: aws.String(queueName)})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to get URL of queue %v", queueName)
+	}
+	return aws.ToString(out.QueueUrl), nil
+}
+
+func (p *Provider) PollForNewMessages(ctx context.Context, queue string, mode models.PollMode) ([]*models.Message, error) {
+	input := &sqs.ReceiveMessageInput{
+		QueueUrl:              aws.String(queue),
+		MaxNumberOfMessages:   10,
+		WaitTimeSeconds:       20,
+		AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
+		MessageAttributeNames: []string{"All"},
+	}
+	if mode == models.PollModePeek {
+		input.VisibilityTimeout = peekVisibilityTimeout
+	}
+
+	out, err := p.client.ReceiveMessage(ctx, input)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to receive messages from queue %v", queue)
 	}
@@ -51,22 +261,20 @@ func (p *Provider) PollForNewMessages(ctx context.Context, queue string) ([]*mod
 	messagesToReturn := make([]*models.Message, 0, len(out.Messages))
 	messagesToDelete := make([]types.DeleteMessageBatchRequestEntry, 0, len(out.Messages))
 	for _, msg := range out.Messages {
-		newLocalMessage := &models.Message{
-			Queue:    queue,
-			ExtID:    aws.ToString(msg.MessageId),
-			Received: time.Now(),
-			Data:     aws.ToString(msg.Body),
-		}
+		newLocalMessage := messageFromSQS(queue, msg)
 		messagesToReturn = append(messagesToReturn, newLocalMessage)
 
-		// Pull the message from the queue
-		// TODO: should this be determined by the caller?
 		messagesToDelete = append(messagesToDelete, types.DeleteMessageBatchRequestEntry{
 			Id:            msg.MessageId,
 			ReceiptHandle: msg.ReceiptHandle,
 		})
 	}
 
+	// Messages received in peek mode are left on the queue
+	if mode == models.PollModePeek {
+		return messagesToReturn, nil
+	}
+
 	if _, err := p.client.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
 		QueueUrl: aws.String(queue),
 		Entries:  messagesToDelete,
@@ -76,3 +284,209 @@ func (p *Provider) PollForNewMessages(ctx context.Context, queue string) ([]*mod
 
 	return messagesToReturn, nil
 }
+
+// ReceiveMessages receives messages from the queue without deleting them, waiting up to the wait time for
+// messages to arrive.  The received messages are hidden from other consumers for the visibility timeout.
+func (p *Provider) ReceiveMessages(ctx context.Context, queue string, waitTime time.Duration, visibilityTimeout time.Duration) ([]*models.Message, error) {
+	out, err := p.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:              aws.String(queue),
+		MaxNumberOfMessages:   maxBatchSize,
+		WaitTimeSeconds:       int32(waitTime / time.Second),
+		VisibilityTimeout:     int32(visibilityTimeout / time.Second),
+		AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
+		MessageAttributeNames: []string{"All"},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to receive messages from queue %v", queue)
+	}
+
+	msgs := make([]*models.Message, len(out.Messages))
+	for i, msg := range out.Messages {
+		msgs[i] = messageFromSQS(queue, msg)
+	}
+	return msgs, nil
+}
+
+// DeleteMessages deletes received messages from the queue.
+func (p *Provider) DeleteMessages(ctx context.Context, queue string, receiptHandles []string) error {
+	for len(receiptHandles) > 0 {
+		batch := receiptHandles
+		if len(batch) > maxBatchSize {
+			batch = batch[:maxBatchSize]
+		}
+		receiptHandles = receiptHandles[len(batch):]
+
+		entries := make([]types.DeleteMessageBatchRequestEntry, len(batch))
+		for i, receiptHandle := range batch {
+			entries[i] = types.DeleteMessageBatchRequestEntry{
+				Id:            aws.String(strconv.Itoa(i)),
+				ReceiptHandle: aws.String(receiptHandle),
+			}
+		}
+
+		out, err := p.client.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
+			QueueUrl: aws.String(queue),
+			Entries:  entries,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "unable to delete messages from queue %v", queue)
+		}
+		for _, failed := range out.Failed {
+			log.Printf("unable to delete message from queue %v: %v", queue, aws.ToString(failed.Message))
+		}
+	}
+	return nil
+}
+
+// DeleteMessage deletes a received message from the queue.
+func (p *Provider) DeleteMessage(ctx context.Context, queue string, receiptHandle string) error {
+	if _, err := p.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queue),
+		ReceiptHandle: aws.String(receiptHandle),
+	}); err != nil {
+		return errors.Wrapf(err, "unable to delete message from queue %v", queue)
+	}
+	return nil
+}
+
+// ReleaseMessages makes received messages immediately visible to other consumers of the queue.
+func (p *Provider) ReleaseMessages(ctx context.Context, queue string, receiptHandles []string) error {
+	for len(receiptHandles) > 0 {
+		batch := receiptHandles
+		if len(batch) > maxBatchSize {
+			batch = batch[:maxBatchSize]
+		}
+		receiptHandles = receiptHandles[len(batch):]
+
+		entries := make([]types.ChangeMessageVisibilityBatchRequestEntry, len(batch))
+		for i, receiptHandle := range batch {
+			entries[i] = types.ChangeMessageVisibilityBatchRequestEntry{
+				Id:                aws.String(strconv.Itoa(i)),
+				ReceiptHandle:     aws.String(receiptHandle),
+				VisibilityTimeout: 0,
+			}
+		}
+
+		out, err := p.client.ChangeMessageVisibilityBatch(ctx, &sqs.ChangeMessageVisibilityBatchInput{
+			QueueUrl: aws.String(queue),
+			Entries:  entries,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "unable to release messages on queue %v", queue)
+		}
+		for _, failed := range out.Failed {
+			log.Printf("unable to release message on queue %v: %v", queue, aws.ToString(failed.Message))
+		}
+	}
+	return nil
+}
+
+func messageFromSQS(queue string, msg types.Message) *models.Message {
+	newLocalMessage := &models.Message{
+		Queue:                  queue,
+		ExtID:                  aws.ToString(msg.MessageId),
+		Received:               time.Now(),
+		Data:                   aws.ToString(msg.Body),
+		ReceiptHandle:          aws.ToString(msg.ReceiptHandle),
+		Attributes:             attributesFromSQS(msg.MessageAttributes),
+		MessageGroupID:         msg.Attributes[string(types.MessageSystemAttributeNameMessageGroupId)],
+		MessageDeduplicationID: msg.Attributes[string(types.MessageSystemAttributeNameMessageDeduplicationId)],
+		SenderID:               msg.Attributes[string(types.MessageSystemAttributeNameSenderId)],
+	}
+
+	if sentTimestamp, err := strconv.ParseInt(msg.Attributes[string(types.MessageSystemAttributeNameSentTimestamp)], 10, 64); err == nil {
+		newLocalMessage.SentTimestamp = time.UnixMilli(sentTimestamp)
+	}
+	if receiveCount, err := strconv.Atoi(msg.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)]); err == nil {
+		newLocalMessage.ApproximateReceiveCount = receiveCount
+	}
+
+	return newLocalMessage
+}
+
+func attributesFromSQS(attrs map[string]types.MessageAttributeValue) map[string]models.MessageAttribute {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	modelAttrs := make(map[string]models.MessageAttribute, len(attrs))
+	for name, attr := range attrs {
+		modelAttrs[name] = models.MessageAttribute{
+			DataType:    aws.ToString(attr.DataType),
+			StringValue: aws.ToString(attr.StringValue),
+			BinaryValue: attr.BinaryValue,
+		}
+	}
+	return modelAttrs
+}
+
+func attributesToSQS(attrs map[string]models.MessageAttribute) map[string]types.MessageAttributeValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	sqsAttrs := make(map[string]types.MessageAttributeValue, len(attrs))
+	for name, attr := range attrs {
+		sqsAttr := types.MessageAttributeValue{DataType: aws.String(attr.DataType)}
+		if attr.IsBinary() {
+			sqsAttr.BinaryValue = attr.BinaryValue
+		} else {
+			sqsAttr.StringValue = aws.String(attr.StringValue)
+		}
+		sqsAttrs[name] = sqsAttr
+	}
+	return sqsAttrs
+}
+
+// nextBatchEnd returns the end of the batch of messages starting at batchStart.  A batch is closed once it has the
+// maximum number of entries, or before the next message would take it over the maximum payload size.  A batch always
+// has at least one message, even if that message is over the maximum payload size.
+func nextBatchEnd(msgs []models.Message, batchStart int) int {
+	batchEnd, payloadSize := batchStart, 0
+	for batchEnd < len(msgs) && batchEnd-batchStart < maxBatchSize {
+		msgSize := messagePayloadSize(msgs[batchEnd])
+		if batchEnd > batchStart && payloadSize+msgSize > maxBatchPayloadSize {
+			break
+		}
+		payloadSize += msgSize
+		batchEnd++
+	}
+	return batchEnd
+}
+
+// messagePayloadSize returns the size of a message as counted towards the payload size limits of SQS, which
+// includes the body and the name, data type and value of each message attribute.
+func messagePayloadSize(msg models.Message) int {
+	size := len(msg.Data)
+	for name, attr := range msg.Attributes {
+		size += len(name) + len(attr.DataType) + len(attr.StringValue) + len(attr.BinaryValue)
+	}
+	return size
+}
+
+// fifoIDs returns the group and deduplication IDs of a message.  These are only set on messages sent to FIFO queues.
+func fifoIDs(msg models.Message) (groupID *string, deduplicationID *string) {
+	if msg.MessageGroupID != "" {
+		groupID = aws.String(msg.MessageGroupID)
+	}
+	if msg.MessageDeduplicationID != "" {
+		deduplicationID = aws.String(msg.MessageDeduplicationID)
+	}
+	return groupID, deduplicationID
+}
+
+// deadLetterTargetArn returns the ARN of the dead-letter queue from a queue's redrive policy.
+func deadLetterTargetArn(redrivePolicyJSON string) string {
+	if redrivePolicyJSON == "" {
+		return ""
+	}
+
+	var redrivePolicy struct {
+		DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+	}
+	if err := json.Unmarshal([]byte(redrivePolicyJSON), &redrivePolicy); err != nil {
+		log.Printf("cannot parse redrive policy: %v", err)
+		return ""
+	}
+	return redrivePolicy.DeadLetterTargetArn
+}
@@ -12,7 +12,6 @@ type Store struct {
 	db *storm.DB
 }
 
-// TODO: should probably be a workspace provider
 func NewStore(filename string) (*Store, error) {
 	db, err := storm.Open(filename)
 	if err != nil {
@@ -29,3 +28,19 @@ func (s *Store) Close() {
 func (s *Store) Save(ctx context.Context, msg *models.Message) error {
 	return s.db.Save(msg)
 }
+
+// List returns all the messages in the store in the order they were saved.
+func (s *Store) List(ctx context.Context) ([]models.Message, error) {
+	var msgs []models.Message
+	if err := s.db.All(&msgs); err != nil {
+		return nil, errors.Wrap(err, "cannot list messages")
+	}
+	return msgs, nil
+}
+
+func (s *Store) Delete(ctx context.Context, msg *models.Message) error {
+	if err := s.db.DeleteStruct(msg); err != nil {
+		return errors.Wrapf(err, "cannot delete message %v", msg.ExtID)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package drain
+
+import (
+	"context"
+	"time"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
+
+type MessageReceiver interface {
+	ReceiveMessages(ctx context.Context, queue string, waitTime time.Duration, visibilityTimeout time.Duration) ([]*models.Message, error)
+	DeleteMessages(ctx context.Context, queue string, receiptHandles []string) error
+	ReleaseMessages(ctx context.Context, queue string, receiptHandles []string) error
+}
+
+// MessageWriter writes drained messages.
+type MessageWriter interface {
+	WriteMessage(msg models.Message) error
+}
@@ -0,0 +1,150 @@
+package drain
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/lmika/audax/internal/sqs-browse/models/filterexpr"
+	"github.com/pkg/errors"
+)
+
+const (
+	// emptyWaitTime is how long to wait for messages before treating the queue as empty.
+	emptyWaitTime = 1 * time.Second
+
+	// longPollWaitTime is how long to wait for messages when waiting for new messages to arrive.
+	longPollWaitTime = 20 * time.Second
+
+	// holdVisibilityTimeout is how long received messages are hidden from other consumers while the queue
+	// is being drained.  Messages left on the queue are released once draining stops.
+	holdVisibilityTimeout = 30 * time.Second
+)
+
+// Options control which messages are drained and when draining stops.
+type Options struct {
+	// Keep leaves drained messages on the queue instead of deleting them
+	Keep bool
+
+	// Filter only drains messages matching the filter, leaving others on the queue.  All messages are drained
+	// if nil.
+	Filter *filterexpr.FilterExpr
+
+	// MaxMessages stops draining once this many messages have been drained.  There is no limit if zero.
+	MaxMessages int
+
+	// Wait keeps waiting for new messages using long polling once the queue is empty.  Draining then only stops
+	// once the message limit is reached or the context is done.
+	Wait bool
+}
+
+// Result is the outcome of draining a queue.
+type Result struct {
+	// Drained is the number of messages written
+	Drained int
+
+	// Skipped is the number of messages that did not match the filter
+	Skipped int
+}
+
+type Service struct {
+	receiver MessageReceiver
+}
+
+func NewService(receiver MessageReceiver) *Service {
+	return &Service{receiver: receiver}
+}
+
+// Drain receives messages from the queue and writes them to the writer, deleting written messages from the queue
+// unless they are being kept.  Draining stops once the queue is empty, the message limit is reached, or the
+// context is done, which is not treated as an error.  Messages left on the queue are released once draining stops.
+func (s *Service) Drain(ctx context.Context, queue string, writer MessageWriter, opts Options) (res Result, err error) {
+	// held are the receipt handles of messages left on the queue, keyed by message ID
+	held := make(map[string]string)
+	defer func() {
+		if releaseErr := s.release(queue, held); releaseErr != nil && err == nil {
+			err = releaseErr
+		}
+	}()
+
+	waitTime := emptyWaitTime
+	if opts.Wait {
+		waitTime = longPollWaitTime
+	}
+
+	for opts.MaxMessages == 0 || res.Drained < opts.MaxMessages {
+		msgs, err := s.receiver.ReceiveMessages(ctx, queue, waitTime, holdVisibilityTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return res, nil
+			}
+			return res, err
+		}
+
+		newMessages := 0
+		toDelete := make([]string, 0, len(msgs))
+		for _, msg := range msgs {
+			// Held messages are received again once their visibility timeout expires
+			if _, isHeld := held[msg.ExtID]; isHeld {
+				held[msg.ExtID] = msg.ReceiptHandle
+				continue
+			}
+			newMessages++
+
+			if opts.MaxMessages > 0 && res.Drained >= opts.MaxMessages {
+				held[msg.ExtID] = msg.ReceiptHandle
+				continue
+			} else if opts.Filter != nil && !opts.Filter.Matches(*msg) {
+				held[msg.ExtID] = msg.ReceiptHandle
+				res.Skipped++
+				continue
+			}
+
+			if err := writer.WriteMessage(*msg); err != nil {
+				held[msg.ExtID] = msg.ReceiptHandle
+				if deleteErr := s.delete(queue, toDelete); deleteErr != nil {
+					log.Printf("warn: %v", deleteErr)
+				}
+				return res, errors.Wrapf(err, "unable to write message %v", msg.ExtID)
+			}
+			res.Drained++
+
+			if opts.Keep {
+				held[msg.ExtID] = msg.ReceiptHandle
+			} else {
+				toDelete = append(toDelete, msg.ReceiptHandle)
+			}
+		}
+
+		if err := s.delete(queue, toDelete); err != nil {
+			return res, err
+		}
+
+		if newMessages == 0 && !opts.Wait {
+			break
+		}
+	}
+	return res, nil
+}
+
+// delete deletes the written messages.  A new context is used so that messages are deleted even if the drain
+// was stopped.
+func (s *Service) delete(queue string, receiptHandles []string) error {
+	if len(receiptHandles) == 0 {
+		return nil
+	}
+	return s.receiver.DeleteMessages(context.Background(), queue, receiptHandles)
+}
+
+// release makes the messages left on the queue visible to other consumers again.
+func (s *Service) release(queue string, held map[string]string) error {
+	if len(held) == 0 {
+		return nil
+	}
+
+	receiptHandles := make([]string, 0, len(held))
+	for _, receiptHandle := range held {
+		receiptHandles = append(receiptHandles, receiptHandle)
+	}
+	return s.receiver.ReleaseMessages(context.Background(), queue, receiptHandles)
+}
@@ -0,0 +1,143 @@
+package drain_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/models/filterexpr"
+	"github.com/lmika/audax/internal/sqs-browse/services/drain"
+	"github.com/stretchr/testify/assert"
+)
+
+const testQueue = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+
+func TestService_Drain(t *testing.T) {
+	t.Run("should write and delete all messages until the queue is empty", func(t *testing.T) {
+		receiver := newFakeReceiver(25)
+		writer := &fakeWriter{}
+
+		res, err := drain.NewService(receiver).Drain(context.Background(), testQueue, writer, drain.Options{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 25, res.Drained)
+		assert.Len(t, writer.written, 25)
+		assert.Len(t, receiver.deleted, 25)
+		assert.Empty(t, receiver.released)
+	})
+
+	t.Run("should stop after the message limit, releasing messages not drained", func(t *testing.T) {
+		receiver := newFakeReceiver(25)
+		writer := &fakeWriter{}
+
+		res, err := drain.NewService(receiver).Drain(context.Background(), testQueue, writer, drain.Options{MaxMessages: 15})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 15, res.Drained)
+		assert.Equal(t, []string{"msg-0", "msg-1", "msg-2"}, writer.written[:3])
+		assert.Len(t, receiver.deleted, 15)
+		assert.Len(t, receiver.released, 5)
+	})
+
+	t.Run("should leave messages on the queue when keeping them", func(t *testing.T) {
+		receiver := newFakeReceiver(5)
+		writer := &fakeWriter{}
+
+		res, err := drain.NewService(receiver).Drain(context.Background(), testQueue, writer, drain.Options{Keep: true})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 5, res.Drained)
+		assert.Empty(t, receiver.deleted)
+		assert.Len(t, receiver.released, 5)
+	})
+
+	t.Run("should only drain messages matching the filter", func(t *testing.T) {
+		receiver := newFakeReceiver(10)
+		writer := &fakeWriter{}
+		filter, err := filterexpr.Parse(`$.n >= 7`)
+		assert.NoError(t, err)
+
+		res, err := drain.NewService(receiver).Drain(context.Background(), testQueue, writer, drain.Options{Filter: filter})
+		assert.NoError(t, err)
+
+		assert.Equal(t, drain.Result{Drained: 3, Skipped: 7}, res)
+		assert.Equal(t, []string{"msg-7", "msg-8", "msg-9"}, writer.written)
+		assert.Equal(t, []string{"handle-7", "handle-8", "handle-9"}, receiver.deleted)
+		assert.Len(t, receiver.released, 7)
+	})
+
+	t.Run("should keep waiting for messages until the context is done", func(t *testing.T) {
+		receiver := newFakeReceiver(3)
+		writer := &fakeWriter{}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		receiver.onEmpty = cancel
+
+		res, err := drain.NewService(receiver).Drain(ctx, testQueue, writer, drain.Options{Wait: true})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 3, res.Drained)
+		assert.Equal(t, 20*time.Second, receiver.lastWaitTime)
+	})
+}
+
+type fakeReceiver struct {
+	pending      []*models.Message
+	deleted      []string
+	released     []string
+	lastWaitTime time.Duration
+	onEmpty      func()
+}
+
+func newFakeReceiver(count int) *fakeReceiver {
+	fr := &fakeReceiver{}
+	for i := 0; i < count; i++ {
+		fr.pending = append(fr.pending, &models.Message{
+			ExtID:         fmt.Sprintf("msg-%d", i),
+			ReceiptHandle: fmt.Sprintf("handle-%d", i),
+			Data:          fmt.Sprintf(`{"n":%d}`, i),
+		})
+	}
+	return fr
+}
+
+func (fr *fakeReceiver) ReceiveMessages(ctx context.Context, queue string, waitTime time.Duration, visibilityTimeout time.Duration) ([]*models.Message, error) {
+	fr.lastWaitTime = waitTime
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(fr.pending) == 0 && fr.onEmpty != nil {
+		fr.onEmpty()
+	}
+
+	batch := fr.pending
+	if len(batch) > 10 {
+		batch = batch[:10]
+	}
+	fr.pending = fr.pending[len(batch):]
+	return batch, nil
+}
+
+func (fr *fakeReceiver) DeleteMessages(ctx context.Context, queue string, receiptHandles []string) error {
+	fr.deleted = append(fr.deleted, receiptHandles...)
+	return nil
+}
+
+func (fr *fakeReceiver) ReleaseMessages(ctx context.Context, queue string, receiptHandles []string) error {
+	fr.released = append(fr.released, receiptHandles...)
+	sort.Strings(fr.released)
+	return nil
+}
+
+type fakeWriter struct {
+	written []string
+}
+
+func (fw *fakeWriter) WriteMessage(msg models.Message) error {
+	fw.written = append(fw.written, msg.ExtID)
+	return nil
+}
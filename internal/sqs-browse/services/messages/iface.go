@@ -7,5 +7,7 @@ import (
 )
 
 type MessageSender interface {
-	SendMessage(ctx context.Context, msg models.Message, queue string) (string, error)
+	SendMessage(ctx context.Context, msg models.Message, queue string, opts models.SendOptions) (string, error)
+	SendMessageBatch(ctx context.Context, msgs []models.Message, queue string, opts models.SendOptions) []error
+	DeadLetterSourceQueues(ctx context.Context, dlq string) ([]string, error)
 }
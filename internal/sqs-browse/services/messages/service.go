@@ -7,20 +7,138 @@ import (
 	"github.com/pkg/errors"
 )
 
+// maxBatchSize is the number of messages sent in each batch
+const maxBatchSize = 10
+
 type Service struct {
 	messageSender MessageSender
+	fifoOptions   models.FIFOOptions
 }
 
-func NewService(messageSender MessageSender) *Service {
+func NewService(messageSender MessageSender, fifoOptions models.FIFOOptions) *Service {
+	if fifoOptions.GroupID == "" {
+		fifoOptions.GroupID = models.DefaultMessageGroupID
+	}
+	if fifoOptions.Deduplication == "" {
+		fifoOptions.Deduplication = models.DeduplicationContentHash
+	}
+
 	return &Service{
 		messageSender: messageSender,
+		fifoOptions:   fifoOptions,
 	}
 }
 
-func (s *Service) SendTo(ctx context.Context, msg models.Message, destQueue string) (string, error) {
-	messageId, err := s.messageSender.SendMessage(ctx, msg, destQueue)
+func (s *Service) SendTo(ctx context.Context, msg models.Message, destQueue string, opts models.SendOptions) (string, error) {
+	if err := validateSendOptions(opts, destQueue); err != nil {
+		return "", err
+	}
+
+	messageId, err := s.messageSender.SendMessage(ctx, s.prepare(msg, destQueue, opts), destQueue, opts)
 	if err != nil {
 		return "", errors.Wrapf(err, "cannot send message to %v", destQueue)
 	}
 	return messageId, nil
 }
+
+// SendBatch sends the messages to the destination queue, preserving their attributes.  The returned slice
+// holds the send error of each message, which is nil if the message was sent successfully.
+//
+// Messages sent to FIFO queues are sent in order, one batch at a time.  Once a message fails to send, the messages
+// of the same group in later batches are not sent, so that the group is not delivered out of order any further.
+func (s *Service) SendBatch(ctx context.Context, msgs []models.Message, destQueue string, opts models.SendOptions) ([]error, error) {
+	if err := validateSendOptions(opts, destQueue); err != nil {
+		return nil, err
+	}
+
+	preparedMsgs := make([]models.Message, len(msgs))
+	for i, msg := range msgs {
+		preparedMsgs[i] = s.prepare(msg, destQueue, opts)
+	}
+
+	if !models.IsFIFOQueue(destQueue) {
+		return s.messageSender.SendMessageBatch(ctx, preparedMsgs, destQueue, opts), nil
+	}
+
+	sendErrs := make([]error, len(msgs))
+	failedGroups := make(map[string]struct{})
+	for batchStart := 0; batchStart < len(preparedMsgs); batchStart += maxBatchSize {
+		batchEnd := batchStart + maxBatchSize
+		if batchEnd > len(preparedMsgs) {
+			batchEnd = len(preparedMsgs)
+		}
+
+		batch := make([]models.Message, 0, maxBatchSize)
+		batchIdxs := make([]int, 0, maxBatchSize)
+		for i := batchStart; i < batchEnd; i++ {
+			if _, groupFailed := failedGroups[preparedMsgs[i].MessageGroupID]; groupFailed {
+				sendErrs[i] = errors.Errorf("not sent as an earlier message in group %v failed", preparedMsgs[i].MessageGroupID)
+				continue
+			}
+			batch = append(batch, preparedMsgs[i])
+			batchIdxs = append(batchIdxs, i)
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		batchErrs := s.messageSender.SendMessageBatch(ctx, batch, destQueue, opts)
+		for i, err := range batchErrs {
+			if err != nil {
+				sendErrs[batchIdxs[i]] = err
+				failedGroups[batch[i].MessageGroupID] = struct{}{}
+			}
+		}
+	}
+	return sendErrs, nil
+}
+
+// prepare returns the message to send to the destination queue.  Messages sent to FIFO queues are given a group
+// and deduplication ID, which are removed from messages sent to standard queues.
+func (s *Service) prepare(msg models.Message, destQueue string, opts models.SendOptions) models.Message {
+	if !models.IsFIFOQueue(destQueue) {
+		msg.MessageGroupID = ""
+		msg.MessageDeduplicationID = ""
+		return msg
+	}
+
+	switch {
+	case opts.GroupID != "":
+		msg.MessageGroupID = opts.GroupID
+	case msg.MessageGroupID == "":
+		msg.MessageGroupID = s.fifoOptions.GroupID
+	}
+
+	if opts.DeduplicationID != "" {
+		msg.MessageDeduplicationID = opts.DeduplicationID
+	} else {
+		msg.MessageDeduplicationID = s.fifoOptions.Deduplication.DeduplicationID(msg)
+	}
+	return msg
+}
+
+// RedriveQueue returns the queue to redrive messages from the dead-letter queue to.  This is the queue with a
+// redrive policy targeting the dead-letter queue.
+func (s *Service) RedriveQueue(ctx context.Context, dlq string) (string, error) {
+	sourceQueues, err := s.messageSender.DeadLetterSourceQueues(ctx, dlq)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(sourceQueues) {
+	case 0:
+		return "", errors.Errorf("no queue has %v as its dead-letter queue", models.QueueName(dlq))
+	case 1:
+		return sourceQueues[0], nil
+	}
+	return "", errors.Errorf("%v is the dead-letter queue of %d queues: specify the queue to redrive to", models.QueueName(dlq), len(sourceQueues))
+}
+
+func validateSendOptions(opts models.SendOptions, destQueue string) error {
+	if opts.Delay < 0 || opts.Delay > models.MaxSendDelay {
+		return errors.Errorf("delay must be between 0 and %v", models.MaxSendDelay)
+	} else if opts.Delay > 0 && models.IsFIFOQueue(destQueue) {
+		return errors.New("delays cannot be set on messages sent to FIFO queues")
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package messages_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/services/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	standardQueue = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	fifoQueue     = "https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo"
+)
+
+func TestService_SendTo(t *testing.T) {
+	t.Run("should preserve group ID and generate deduplication ID when sending to FIFO queue", func(t *testing.T) {
+		sender := &fakeSender{}
+		service := messages.NewService(sender, models.FIFOOptions{})
+
+		_, err := service.SendTo(context.Background(), models.Message{Data: "hello", MessageGroupID: "customer-1"}, fifoQueue, models.SendOptions{})
+		assert.NoError(t, err)
+
+		hash := sha256.Sum256([]byte("hello"))
+		assert.Len(t, sender.sent, 1)
+		assert.Equal(t, "customer-1", sender.sent[0].MessageGroupID)
+		assert.Equal(t, hex.EncodeToString(hash[:]), sender.sent[0].MessageDeduplicationID)
+	})
+
+	t.Run("should use default group ID and original ID when configured", func(t *testing.T) {
+		sender := &fakeSender{}
+		service := messages.NewService(sender, models.FIFOOptions{GroupID: "forwarded", Deduplication: models.DeduplicationOriginal})
+
+		_, err := service.SendTo(context.Background(), models.Message{ExtID: "abc-123", Data: "hello"}, fifoQueue, models.SendOptions{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "forwarded", sender.sent[0].MessageGroupID)
+		assert.Equal(t, "abc-123", sender.sent[0].MessageDeduplicationID)
+	})
+
+	t.Run("should use IDs from send options over those of the message", func(t *testing.T) {
+		sender := &fakeSender{}
+		service := messages.NewService(sender, models.FIFOOptions{})
+
+		_, err := service.SendTo(context.Background(), models.Message{Data: "hello", MessageGroupID: "customer-1"}, fifoQueue,
+			models.SendOptions{GroupID: "customer-2", DeduplicationID: "dedup-1"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "customer-2", sender.sent[0].MessageGroupID)
+		assert.Equal(t, "dedup-1", sender.sent[0].MessageDeduplicationID)
+	})
+
+	t.Run("should remove FIFO IDs when sending to standard queue", func(t *testing.T) {
+		sender := &fakeSender{}
+		service := messages.NewService(sender, models.FIFOOptions{})
+
+		_, err := service.SendTo(context.Background(), models.Message{Data: "hello", MessageGroupID: "customer-1", MessageDeduplicationID: "dedup-1"},
+			standardQueue, models.SendOptions{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "", sender.sent[0].MessageGroupID)
+		assert.Equal(t, "", sender.sent[0].MessageDeduplicationID)
+	})
+
+	t.Run("should not allow delays when sending to FIFO queue", func(t *testing.T) {
+		sender := &fakeSender{}
+		service := messages.NewService(sender, models.FIFOOptions{})
+
+		_, err := service.SendTo(context.Background(), models.Message{Data: "hello"}, fifoQueue, models.SendOptions{Delay: 5 * time.Second})
+		assert.Error(t, err)
+		assert.Empty(t, sender.sent)
+	})
+}
+
+func TestService_SendBatch(t *testing.T) {
+	t.Run("should send messages to FIFO queue in order", func(t *testing.T) {
+		sender := &fakeSender{}
+		service := messages.NewService(sender, models.FIFOOptions{})
+
+		msgs := make([]models.Message, 25)
+		for i := range msgs {
+			msgs[i] = models.Message{Data: fmt.Sprint(i)}
+		}
+
+		errs, err := service.SendBatch(context.Background(), msgs, fifoQueue, models.SendOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, errs, 25)
+		assert.Equal(t, []int{10, 10, 5}, sender.batchSizes)
+		for i, msg := range sender.sent {
+			assert.Equal(t, fmt.Sprint(i), msg.Data)
+			assert.Equal(t, models.DefaultMessageGroupID, msg.MessageGroupID)
+		}
+	})
+
+	t.Run("should not send later messages of a group once a message of that group fails", func(t *testing.T) {
+		sender := &fakeSender{failData: map[string]bool{"a9": true}}
+		service := messages.NewService(sender, models.FIFOOptions{})
+
+		var msgs []models.Message
+		for i := 0; i < 10; i++ {
+			msgs = append(msgs, models.Message{Data: fmt.Sprintf("a%d", i), MessageGroupID: "a"})
+		}
+		msgs = append(msgs,
+			models.Message{Data: "a10", MessageGroupID: "a"},
+			models.Message{Data: "b0", MessageGroupID: "b"},
+			models.Message{Data: "a11", MessageGroupID: "a"},
+			models.Message{Data: "b1", MessageGroupID: "b"})
+
+		errs, err := service.SendBatch(context.Background(), msgs, fifoQueue, models.SendOptions{})
+		assert.NoError(t, err)
+
+		var sentData []string
+		for _, msg := range sender.sent {
+			sentData = append(sentData, msg.Data)
+		}
+		assert.Equal(t, []string{"a0", "a1", "a2", "a3", "a4", "a5", "a6", "a7", "a8", "b0", "b1"}, sentData)
+
+		for i, msg := range msgs {
+			switch msg.Data {
+			case "a9", "a10", "a11":
+				assert.Error(t, errs[i], msg.Data)
+			default:
+				assert.NoError(t, errs[i], msg.Data)
+			}
+		}
+	})
+}
+
+type fakeSender struct {
+	failData   map[string]bool
+	sent       []models.Message
+	batchSizes []int
+}
+
+func (fs *fakeSender) SendMessage(ctx context.Context, msg models.Message, queue string, opts models.SendOptions) (string, error) {
+	if fs.failData[msg.Data] {
+		return "", errors.New("send failed")
+	}
+	fs.sent = append(fs.sent, msg)
+	return "msg-id", nil
+}
+
+func (fs *fakeSender) SendMessageBatch(ctx context.Context, msgs []models.Message, queue string, opts models.SendOptions) []error {
+	fs.batchSizes = append(fs.batchSizes, len(msgs))
+
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		_, errs[i] = fs.SendMessage(ctx, msg, queue, opts)
+	}
+	return errs
+}
+
+func (fs *fakeSender) DeadLetterSourceQueues(ctx context.Context, dlq string) ([]string, error) {
+	return nil, nil
+}
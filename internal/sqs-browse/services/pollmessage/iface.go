@@ -11,5 +11,7 @@ type MessageStore interface {
 }
 
 type MessagePoller interface {
-	PollForNewMessages(ctx context.Context, queue string) ([]*models.Message, error)
+	PollForNewMessages(ctx context.Context, queue string, mode models.PollMode) ([]*models.Message, error)
+	DeleteMessage(ctx context.Context, queue string, receiptHandle string) error
+	ReleaseMessages(ctx context.Context, queue string, receiptHandles []string) error
 }
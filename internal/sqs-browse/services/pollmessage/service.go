@@ -3,7 +3,9 @@ package pollmessage
 import (
 	"context"
 	"log"
+	"sync"
 
+	"github.com/lmika/audax/internal/sqs-browse/models"
 	"github.com/lmika/events"
 	"github.com/pkg/errors"
 )
@@ -11,36 +13,197 @@ import (
 type Service struct {
 	store  MessageStore
 	poller MessagePoller
-	queue  string
+	mode   models.PollMode
 	bus    *events.Bus
+
+	mutex    *sync.Mutex
+	queue    string
+	stopPoll context.CancelFunc
+	pollDone chan struct{}
+	seen     map[string]struct{}
+
+	// held are the receipt handles of messages received in peek mode, keyed by the message ID.  These are
+	// used to delete the messages.
+	held map[string]string
+
+	// unreleased are the receipt handles of messages received in peek mode which are still hidden from other
+	// consumers.  These are released once polling stops.
+	unreleased []string
 }
 
-func NewService(store MessageStore, poller MessagePoller, queue string, bus *events.Bus) *Service {
+func NewService(store MessageStore, poller MessagePoller, mode models.PollMode, bus *events.Bus) *Service {
 	return &Service{
 		store:  store,
 		poller: poller,
-		queue:  queue,
+		mode:   mode,
 		bus:    bus,
+		mutex:  new(sync.Mutex),
+		seen:   make(map[string]struct{}),
+		held:   make(map[string]string),
 	}
 }
 
-// Poll starts polling for new messages and adding them to the message store
-func (s *Service) Poll(ctx context.Context) error {
+// Queue returns the queue being polled.
+func (s *Service) Queue() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.queue
+}
+
+func (s *Service) Mode() models.PollMode {
+	return s.mode
+}
+
+// Start starts polling the queue in the background.  If another queue is being polled, polling of that queue is
+// stopped first.  Errors which stop polling are fired as "poll-error" events.
+func (s *Service) Start(ctx context.Context, queue string) {
+	if err := s.Stop(ctx); err != nil {
+		log.Printf("warn: %v", err)
+	}
+
+	pollCtx, stopPoll := context.WithCancel(context.Background())
+	pollDone := make(chan struct{})
+
+	s.mutex.Lock()
+	s.queue = queue
+	s.stopPoll = stopPoll
+	s.pollDone = pollDone
+	s.mutex.Unlock()
+
+	go func() {
+		defer close(pollDone)
+		if err := s.Poll(pollCtx, queue); err != nil {
+			log.Printf("cannot poll %v: %v", queue, err)
+			s.bus.Fire("poll-error", err)
+		}
+	}()
+}
+
+// Stop stops polling.  Messages received in peek mode are released once polling has stopped, and can no longer
+// be deleted.
+func (s *Service) Stop(ctx context.Context) error {
+	s.mutex.Lock()
+	stopPoll, pollDone := s.stopPoll, s.pollDone
+	s.stopPoll, s.pollDone = nil, nil
+	s.mutex.Unlock()
+
+	if stopPoll == nil {
+		return nil
+	}
+	stopPoll()
+	<-pollDone
+
+	s.mutex.Lock()
+	s.held = make(map[string]string)
+	s.mutex.Unlock()
+	return nil
+}
+
+// Poll polls the queue for new messages and adds them to the message store until the context is cancelled.
+//
+// In peek mode, received messages are hidden from other consumers until polling stops, at which point they are
+// released back to the queue.  Each message is received only once, as each receive counts towards the redrive policy
+// of the queue.  Peeking therefore stops once a receive returns no new messages, either because the queue is empty
+// or the held messages have become visible again, and a "peek-complete" event is fired.
+func (s *Service) Poll(ctx context.Context, queue string) error {
+	if s.mode == models.PollModePeek {
+		defer s.releaseHeld(queue)
+	}
+
 	for ctx.Err() == nil {
-		log.Printf("polling for new messages: %v", s.queue)
-		newMsgs, err := s.poller.PollForNewMessages(ctx, s.queue)
+		log.Printf("polling for new messages: %v", queue)
+		newMsgs, err := s.poller.PollForNewMessages(ctx, queue, s.mode)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
 			return errors.Wrap(err, "unable to poll for messages")
 		}
 
-		for _, msg := range newMsgs {
+		unseenMsgs := s.recordReceived(newMsgs)
+		if s.mode == models.PollModePeek && len(unseenMsgs) == 0 {
+			log.Printf("all messages on %v have been peeked", queue)
+			s.bus.Fire("peek-complete", queue)
+			return nil
+		}
+
+		// Messages which cannot be saved, such as those already in the workspace, are not reported
+		savedMsgs := make([]*models.Message, 0, len(unseenMsgs))
+		for _, msg := range unseenMsgs {
 			if err := s.store.Save(ctx, msg); err != nil {
 				log.Printf("warn: unable to save new message %v", err)
 				continue
 			}
+			savedMsgs = append(savedMsgs, msg)
 		}
 
-		s.bus.Fire("new-messages", newMsgs)
+		if len(savedMsgs) > 0 {
+			s.bus.Fire("new-messages", savedMsgs)
+		}
+	}
+	return nil
+}
+
+// recordReceived records the receipt handles of messages received in peek mode, and returns the messages
+// which have not been received before.
+func (s *Service) recordReceived(msgs []*models.Message) []*models.Message {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unseenMsgs := make([]*models.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		if s.mode == models.PollModePeek {
+			s.held[msg.ExtID] = msg.ReceiptHandle
+			s.unreleased = append(s.unreleased, msg.ReceiptHandle)
+		}
+
+		if _, seen := s.seen[msg.ExtID]; seen {
+			continue
+		}
+		s.seen[msg.ExtID] = struct{}{}
+		unseenMsgs = append(unseenMsgs, msg)
+	}
+	return unseenMsgs
+}
+
+// Delete deletes a message received in peek mode from the queue.
+func (s *Service) Delete(ctx context.Context, msg models.Message) error {
+	if s.mode != models.PollModePeek {
+		return errors.New("message was already removed from the queue when it was received")
 	}
+
+	s.mutex.Lock()
+	receiptHandle, isHeld := s.held[msg.ExtID]
+	queue := s.queue
+	s.mutex.Unlock()
+
+	if !isHeld {
+		return errors.Errorf("message %v was not received from %v by this session", msg.ExtID, models.QueueName(queue))
+	}
+
+	if err := s.poller.DeleteMessage(ctx, queue, receiptHandle); err != nil {
+		return errors.Wrapf(err, "cannot delete message %v", msg.ExtID)
+	}
+
+	s.mutex.Lock()
+	delete(s.held, msg.ExtID)
+	s.mutex.Unlock()
 	return nil
 }
+
+// releaseHeld makes the messages received in peek mode visible to other consumers again.
+func (s *Service) releaseHeld(queue string) {
+	s.mutex.Lock()
+	receiptHandles := s.unreleased
+	s.unreleased = nil
+	s.mutex.Unlock()
+
+	if len(receiptHandles) == 0 {
+		return
+	}
+
+	// A new context is used so that the messages are released even if polling was stopped
+	if err := s.poller.ReleaseMessages(context.Background(), queue, receiptHandles); err != nil {
+		log.Printf("warn: %v", err)
+	}
+}
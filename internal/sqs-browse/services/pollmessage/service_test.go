@@ -0,0 +1,110 @@
+package pollmessage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/services/pollmessage"
+	"github.com/lmika/events"
+	"github.com/stretchr/testify/assert"
+)
+
+const testQueue = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+
+func TestService_Poll(t *testing.T) {
+	t.Run("should receive each message once in peek mode and release them once polling stops", func(t *testing.T) {
+		poller := &fakePoller{messageCount: 15}
+		store := &fakeStore{}
+		bus := events.New()
+
+		completed := make(chan string, 1)
+		bus.On("peek-complete", func(queue string) { completed <- queue })
+
+		service := pollmessage.NewService(store, poller, models.PollModePeek, bus)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		assert.NoError(t, service.Poll(ctx, testQueue))
+
+		// Two receives to capture all messages, then one receive returning no messages
+		assert.Equal(t, 3, poller.receives)
+		assert.Len(t, store.saved, 15)
+		assert.Len(t, poller.released, 1)
+		assert.Len(t, poller.released[0], 15)
+		assert.Equal(t, testQueue, <-completed)
+	})
+
+	t.Run("should stop peeking once held messages are received again", func(t *testing.T) {
+		poller := &fakePoller{messageCount: 5, redeliver: true}
+		store := &fakeStore{}
+
+		service := pollmessage.NewService(store, poller, models.PollModePeek, events.New())
+		assert.NoError(t, service.Poll(context.Background(), testQueue))
+
+		assert.Equal(t, 2, poller.receives)
+		assert.Len(t, store.saved, 5)
+		assert.Len(t, poller.released, 1)
+		assert.Len(t, poller.released[0], 10)
+	})
+
+	t.Run("should allow deleting peeked messages", func(t *testing.T) {
+		poller := &fakePoller{messageCount: 3}
+		service := pollmessage.NewService(&fakeStore{}, poller, models.PollModePeek, events.New())
+
+		assert.NoError(t, service.Poll(context.Background(), testQueue))
+		assert.NoError(t, service.Delete(context.Background(), models.Message{ExtID: "msg-1"}))
+		assert.Equal(t, []string{"handle-1"}, poller.deleted)
+	})
+}
+
+// fakePoller returns the messages on the queue in batches of 10.  Once all messages have been returned, the
+// queue is empty unless redeliver is set, in which case it starts again from the first message as if the
+// visibility timeout of the messages had expired.
+type fakePoller struct {
+	messageCount int
+	redeliver    bool
+	receives     int
+	offset       int
+	released     [][]string
+	deleted      []string
+}
+
+func (fp *fakePoller) PollForNewMessages(ctx context.Context, queue string, mode models.PollMode) ([]*models.Message, error) {
+	fp.receives++
+
+	var msgs []*models.Message
+	for len(msgs) < 10 && fp.offset < fp.messageCount {
+		msgs = append(msgs, &models.Message{
+			ExtID:         fmt.Sprintf("msg-%d", fp.offset),
+			ReceiptHandle: fmt.Sprintf("handle-%d", fp.offset),
+			Queue:         queue,
+		})
+		fp.offset++
+	}
+	if fp.redeliver && fp.offset >= fp.messageCount {
+		fp.offset = 0
+	}
+	return msgs, nil
+}
+
+func (fp *fakePoller) DeleteMessage(ctx context.Context, queue string, receiptHandle string) error {
+	fp.deleted = append(fp.deleted, receiptHandle)
+	return nil
+}
+
+func (fp *fakePoller) ReleaseMessages(ctx context.Context, queue string, receiptHandles []string) error {
+	fp.released = append(fp.released, receiptHandles)
+	return nil
+}
+
+type fakeStore struct {
+	saved []*models.Message
+}
+
+func (fs *fakeStore) Save(ctx context.Context, msg *models.Message) error {
+	fs.saved = append(fs.saved, msg)
+	return nil
+}
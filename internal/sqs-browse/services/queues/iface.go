@@ -0,0 +1,12 @@
+package queues
+
+import (
+	"context"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
+
+type QueueProvider interface {
+	ListQueues(ctx context.Context) ([]models.QueueInfo, error)
+	QueueURL(ctx context.Context, queueName string) (string, error)
+}
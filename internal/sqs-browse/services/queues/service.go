@@ -0,0 +1,40 @@
+package queues
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
+
+type Service struct {
+	provider QueueProvider
+}
+
+func NewService(provider QueueProvider) *Service {
+	return &Service{
+		provider: provider,
+	}
+}
+
+// List returns the queues sorted by name.
+func (s *Service) List(ctx context.Context) ([]models.QueueInfo, error) {
+	queues, err := s.provider.ListQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i].Name < queues[j].Name
+	})
+	return queues, nil
+}
+
+// QueueURL returns the URL of a queue, which may be given either as a name or a URL.
+func (s *Service) QueueURL(ctx context.Context, queue string) (string, error) {
+	if strings.Contains(queue, "://") {
+		return queue, nil
+	}
+	return s.provider.QueueURL(ctx, queue)
+}
@@ -0,0 +1,13 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
+
+type MessageStore interface {
+	Save(ctx context.Context, msg *models.Message) error
+	List(ctx context.Context) ([]models.Message, error)
+	Delete(ctx context.Context, msg *models.Message) error
+}
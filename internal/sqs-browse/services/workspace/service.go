@@ -0,0 +1,87 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/models/filterexpr"
+	"github.com/pkg/errors"
+)
+
+// Service manages the messages captured within a workspace.
+type Service struct {
+	store MessageStore
+}
+
+func NewService(store MessageStore) *Service {
+	return &Service{
+		store: store,
+	}
+}
+
+func (s *Service) List(ctx context.Context) ([]models.Message, error) {
+	return s.store.List(ctx)
+}
+
+// Filter returns the messages in the workspace matching the filter.  All messages are returned if the filter is nil.
+func (s *Service) Filter(ctx context.Context, filter *filterexpr.FilterExpr) ([]models.Message, error) {
+	msgs, err := s.store.List(ctx)
+	if err != nil || filter == nil {
+		return msgs, err
+	}
+
+	matchingMsgs := make([]models.Message, 0)
+	for _, msg := range msgs {
+		if filter.Matches(msg) {
+			matchingMsgs = append(matchingMsgs, msg)
+		}
+	}
+	return matchingMsgs, nil
+}
+
+// Tag adds the tags to the message, ignoring any the message already has.
+func (s *Service) Tag(ctx context.Context, msg models.Message, tags []string) (models.Message, error) {
+	newTags := append([]string{}, msg.Tags...)
+	for _, tag := range tags {
+		if !msg.HasTag(tag) {
+			newTags = append(newTags, tag)
+		}
+	}
+	msg.Tags = newTags
+	return s.save(ctx, msg)
+}
+
+// Untag removes the tags from the message.
+func (s *Service) Untag(ctx context.Context, msg models.Message, tags []string) (models.Message, error) {
+	tagsToRemove := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tagsToRemove[tag] = struct{}{}
+	}
+
+	newTags := make([]string, 0, len(msg.Tags))
+	for _, tag := range msg.Tags {
+		if _, remove := tagsToRemove[tag]; !remove {
+			newTags = append(newTags, tag)
+		}
+	}
+	msg.Tags = newTags
+	return s.save(ctx, msg)
+}
+
+// Annotate sets the note of the message.  An empty note removes it.
+func (s *Service) Annotate(ctx context.Context, msg models.Message, note string) (models.Message, error) {
+	msg.Note = note
+	return s.save(ctx, msg)
+}
+
+// Delete removes the message from the workspace.  The message is not deleted from the queue.
+func (s *Service) Delete(ctx context.Context, msg models.Message) error {
+	return s.store.Delete(ctx, &msg)
+}
+
+func (s *Service) save(ctx context.Context, msg models.Message) (models.Message, error) {
+	if err := s.store.Save(ctx, &msg); err != nil {
+		return models.Message{}, errors.Wrapf(err, "cannot save message %v", msg.ExtID)
+	}
+	return msg, nil
+}
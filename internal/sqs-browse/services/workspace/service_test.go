@@ -0,0 +1,99 @@
+package workspace_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/models/filterexpr"
+	"github.com/lmika/audax/internal/sqs-browse/providers/stormstore"
+	"github.com/lmika/audax/internal/sqs-browse/services/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Filter(t *testing.T) {
+	t.Run("should return stored messages matching the filter", func(t *testing.T) {
+		ctx := context.Background()
+		store, err := stormstore.NewStore(filepath.Join(t.TempDir(), "test.workspace"))
+		assert.NoError(t, err)
+		t.Cleanup(store.Close)
+
+		msg := &models.Message{ExtID: "abc-123", Queue: "test-queue", Data: `{"hello":"world"}`}
+		assert.NoError(t, store.Save(ctx, msg))
+		assert.NoError(t, store.Save(ctx, &models.Message{ExtID: "def-456", Queue: "test-queue", Data: `{"hello":"there"}`}))
+		service := workspace.NewService(store)
+
+		filter, err := filterexpr.Parse(`$.hello == "world"`)
+		assert.NoError(t, err)
+
+		msgs, err := service.Filter(ctx, filter)
+		assert.NoError(t, err)
+		assert.Len(t, msgs, 1)
+		assert.Equal(t, msg.ExtID, msgs[0].ExtID)
+
+		msgs, err = service.Filter(ctx, nil)
+		assert.NoError(t, err)
+		assert.Len(t, msgs, 2)
+	})
+}
+
+func TestService_Tag(t *testing.T) {
+	t.Run("should add and remove tags from stored messages", func(t *testing.T) {
+		ctx := context.Background()
+		service, msg := newTestService(t)
+
+		msg, err := service.Tag(ctx, msg, []string{"retry", "bad-input"})
+		assert.NoError(t, err)
+		msg, err = service.Tag(ctx, msg, []string{"retry", "escalated"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"retry", "bad-input", "escalated"}, msg.Tags)
+
+		_, err = service.Untag(ctx, msg, []string{"bad-input"})
+		assert.NoError(t, err)
+
+		msgs, err := service.List(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, msgs, 1)
+		assert.Equal(t, []string{"retry", "escalated"}, msgs[0].Tags)
+	})
+}
+
+func TestService_Annotate(t *testing.T) {
+	t.Run("should set the note of a stored message", func(t *testing.T) {
+		ctx := context.Background()
+		service, msg := newTestService(t)
+
+		_, err := service.Annotate(ctx, msg, "customer complaint")
+		assert.NoError(t, err)
+
+		msgs, err := service.List(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "customer complaint", msgs[0].Note)
+		assert.Equal(t, msg.Data, msgs[0].Data)
+	})
+}
+
+func TestService_Delete(t *testing.T) {
+	t.Run("should remove the message from the workspace", func(t *testing.T) {
+		ctx := context.Background()
+		service, msg := newTestService(t)
+
+		assert.NoError(t, service.Delete(ctx, msg))
+
+		msgs, err := service.List(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, msgs)
+	})
+}
+
+func newTestService(t *testing.T) (*workspace.Service, models.Message) {
+	store, err := stormstore.NewStore(filepath.Join(t.TempDir(), "test.workspace"))
+	assert.NoError(t, err)
+	t.Cleanup(store.Close)
+
+	msg := &models.Message{ExtID: "abc-123", Queue: "test-queue", Data: `{"hello":"world"}`}
+	assert.NoError(t, store.Save(context.Background(), msg))
+
+	return workspace.NewService(store), *msg
+}
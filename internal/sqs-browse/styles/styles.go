@@ -7,8 +7,8 @@ import (
 )
 
 type Styles struct {
-	Frames          frame.Style
-	StatusAndPrompt statusandprompt.Style
+	Frames          frame.Style           `config:"frames"`
+	StatusAndPrompt statusandprompt.Style `config:"status"`
 }
 
 var DefaultStyles = Styles{
@@ -16,7 +16,7 @@ var DefaultStyles = Styles{
 		ActiveTitle: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#ffffff")).
-			Background(lipgloss.Color("#4479ff")),
+			Background(lipgloss.Color("#eac610")),
 		InactiveTitle: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#000000")).
 			Background(lipgloss.Color("#d1d1d1")),
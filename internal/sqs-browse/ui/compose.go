@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/pkg/errors"
+)
+
+// composeArgs are the arguments of the compose command
+type composeArgs struct {
+	queue     string
+	file      string
+	jsonlFile string
+	message   models.Message
+	opts      models.SendOptions
+}
+
+// attributeFlags collects the message attributes set using repeated -attr flags
+type attributeFlags map[string]models.MessageAttribute
+
+func (af attributeFlags) String() string {
+	return ""
+}
+
+func (af attributeFlags) Set(s string) error {
+	name, attr, err := models.ParseAttribute(s)
+	if err != nil {
+		return err
+	}
+	af[name] = attr
+	return nil
+}
+
+func parseComposeArgs(args []string) (composeArgs, error) {
+	var ca composeArgs
+	attrs := make(attributeFlags)
+
+	fs := flag.NewFlagSet("compose", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&ca.queue, "q", "", "")
+	fs.StringVar(&ca.file, "f", "", "")
+	fs.StringVar(&ca.jsonlFile, "jsonl", "", "")
+	fs.StringVar(&ca.message.MessageGroupID, "group", "", "")
+	fs.StringVar(&ca.opts.DeduplicationID, "dedup", "", "")
+	fs.Var(attrs, "attr", "")
+	delaySeconds := fs.Int("delay", 0, "")
+	if err := fs.Parse(args); err != nil {
+		return composeArgs{}, errors.Wrap(err, "compose")
+	}
+
+	if ca.file != "" && ca.jsonlFile != "" {
+		return composeArgs{}, errors.New("compose: only one of -f or -jsonl can be specified")
+	} else if (ca.file != "" || ca.jsonlFile != "") && fs.NArg() > 0 {
+		return composeArgs{}, errors.New("compose: body cannot be specified with -f or -jsonl")
+	} else if ca.jsonlFile != "" && ca.opts.DeduplicationID != "" {
+		return composeArgs{}, errors.New("compose: -dedup cannot be used with -jsonl")
+	}
+
+	ca.message.Data = strings.Join(fs.Args(), " ")
+	if len(attrs) > 0 {
+		ca.message.Attributes = attrs
+	}
+	ca.opts.Delay = time.Duration(*delaySeconds) * time.Second
+	return ca, nil
+}
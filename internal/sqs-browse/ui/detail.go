@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
+
+const detailTimeFormat = "2006-01-02 15:04:05 MST"
+
+// messageDetail returns the text shown in the detail pane for the message: the system metadata and attributes
+// followed by the message body.
+func messageDetail(message models.Message) string {
+	sb := new(strings.Builder)
+
+	tw := tabwriter.NewWriter(sb, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "Message ID:\t%v\n", message.ExtID)
+	if !message.SentTimestamp.IsZero() {
+		fmt.Fprintf(tw, "Sent:\t%v\n", message.SentTimestamp.Local().Format(detailTimeFormat))
+	}
+	if message.ApproximateReceiveCount > 0 {
+		fmt.Fprintf(tw, "Receive count:\t%v\n", message.ApproximateReceiveCount)
+	}
+	if message.SenderID != "" {
+		fmt.Fprintf(tw, "Sender ID:\t%v\n", message.SenderID)
+	}
+	if message.MessageGroupID != "" {
+		fmt.Fprintf(tw, "Group ID:\t%v\n", message.MessageGroupID)
+	}
+	if message.MessageDeduplicationID != "" {
+		fmt.Fprintf(tw, "Deduplication ID:\t%v\n", message.MessageDeduplicationID)
+	}
+	if len(message.Tags) > 0 {
+		fmt.Fprintf(tw, "Tags:\t%v\n", strings.Join(message.Tags, ", "))
+	}
+	if message.Note != "" {
+		fmt.Fprintf(tw, "Note:\t%v\n", message.Note)
+	}
+	if len(message.Attributes) > 0 {
+		fmt.Fprintln(tw, "Attributes:\t")
+	}
+	for _, name := range message.AttributeNames() {
+		attr := message.Attributes[name]
+		if attr.IsBinary() {
+			fmt.Fprintf(tw, "  %v:\t%v (%v)\n", name, base64.StdEncoding.EncodeToString(attr.BinaryValue), attr.DataType)
+		} else {
+			fmt.Fprintf(tw, "  %v:\t%v (%v)\n", name, attr.StringValue, attr.DataType)
+		}
+	}
+	tw.Flush()
+
+	sb.WriteString("\n")
+
+	// TODO: not all messages are JSON
+	formattedJson := new(bytes.Buffer)
+	if err := json.Indent(formattedJson, []byte(message.Data), "", "   "); err == nil {
+		sb.WriteString(formattedJson.String())
+	} else {
+		sb.WriteString(message.Data)
+	}
+	return sb.String()
+}
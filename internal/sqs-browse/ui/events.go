@@ -1,5 +1,47 @@
 package ui
 
-import "github.com/lmika/audax/internal/sqs-browse/models"
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
 
 type NewMessagesEvent []*models.Message
+
+// setSearchQuery sets the query used to filter the displayed messages
+type setSearchQuery string
+
+// setFindTerm sets the term to find within the detail of the selected message
+type setFindTerm string
+
+// withSelectedMessage requests that the function is invoked with the selected message
+type withSelectedMessage func(message models.Message) tea.Cmd
+
+func searchFor(query string) tea.Cmd {
+	return func() tea.Msg {
+		return setSearchQuery(query)
+	}
+}
+
+func findInDetail(term string) tea.Cmd {
+	return func() tea.Msg {
+		return setFindTerm(term)
+	}
+}
+
+func onSelectedMessage(fn func(message models.Message) tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return withSelectedMessage(fn)
+	}
+}
+
+// withMarkedMessages requests that the function is invoked with the marked messages
+type withMarkedMessages func(msgs []models.Message) tea.Cmd
+
+// unmarkMessages requests that the messages are unmarked
+type unmarkMessages []models.Message
+
+func onMarkedMessages(fn func(msgs []models.Message) tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return withMarkedMessages(fn)
+	}
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pkg/errors"
+)
+
+var findMatchStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#000000")).
+	Background(lipgloss.Color("#eac610"))
+
+// findPattern returns the pattern matching the find term.  The term is case-insensitive text, or a regular
+// expression if surrounded by slashes, such as "/order-\d+/".
+func findPattern(term string) (*regexp.Regexp, error) {
+	if len(term) >= 2 && strings.HasPrefix(term, "/") && strings.HasSuffix(term, "/") {
+		re, err := regexp.Compile(term[1 : len(term)-1])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid regular expression")
+		}
+		return re, nil
+	}
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(term)), nil
+}
+
+// highlightMatches returns the text with the matches of the pattern highlighted, along with the indices of the
+// lines containing a match.
+func highlightMatches(text string, re *regexp.Regexp) (string, []int) {
+	lines := strings.Split(text, "\n")
+	matchingLines := make([]int, 0)
+	for i, line := range lines {
+		sb := new(strings.Builder)
+		lastEnd, matched := 0, false
+		for _, loc := range re.FindAllStringIndex(line, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			sb.WriteString(line[lastEnd:loc[0]])
+			sb.WriteString(findMatchStyle.Render(line[loc[0]:loc[1]]))
+			lastEnd, matched = loc[1], true
+		}
+		if !matched {
+			continue
+		}
+		sb.WriteString(line[lastEnd:])
+		lines[i] = sb.String()
+		matchingLines = append(matchingLines, i)
+	}
+	return strings.Join(lines, "\n"), matchingLines
+}
+
+// highlightTerm returns the term to highlight in the message detail: the find term, or the search query if
+// nothing is being found.
+func (m uiModel) highlightTerm() (string, *regexp.Regexp) {
+	if m.findPattern != nil {
+		return m.findTerm, m.findPattern
+	} else if m.searchQuery != "" {
+		return m.searchQuery, regexp.MustCompile("(?i)" + regexp.QuoteMeta(m.searchQuery))
+	}
+	return "", nil
+}
+
+// setFindTerm highlights the term within the message detail and scrolls to the first match.  An empty term
+// clears the find.
+func (m *uiModel) setFindTerm(term string) {
+	if term == "" {
+		m.findTerm, m.findPattern = "", nil
+		m.updateViewportToSelectedMessage()
+		m.message = "find cleared"
+		return
+	}
+
+	pattern, err := findPattern(term)
+	if err != nil {
+		m.message = "Error: " + err.Error()
+		return
+	}
+
+	m.findTerm, m.findPattern = term, pattern
+	m.updateViewportToSelectedMessage()
+	m.currentMatch = -1
+	m.jumpToMatch(1)
+}
+
+// jumpToMatch scrolls the message detail to the line of the match offset from the current match, wrapping
+// around at either end of the detail.
+func (m *uiModel) jumpToMatch(offset int) {
+	if len(m.matchingLines) == 0 {
+		if term, _ := m.highlightTerm(); term != "" {
+			m.message = fmt.Sprintf("Error: no matches for '%v'", term)
+		} else {
+			m.message = "Error: nothing to find"
+		}
+		return
+	}
+
+	matchCount := len(m.matchingLines)
+	m.currentMatch = ((m.currentMatch+offset)%matchCount + matchCount) % matchCount
+	m.viewport.SetYOffset(m.matchingLines[m.currentMatch])
+	m.message = fmt.Sprintf("match %d of %d", m.currentMatch+1, matchCount)
+}
@@ -0,0 +1,53 @@
+package keybindings
+
+import "github.com/charmbracelet/bubbles/key"
+
+// KeyBindings are the key bindings used by sqs-browse.  Each binding can be rebound from the user
+// config file using the name within the "config" tags, such as "table.move-up".
+type KeyBindings struct {
+	Table *TableKeyBinding `config:"table"`
+	View  *ViewKeyBindings `config:"view"`
+}
+
+type TableKeyBinding struct {
+	MoveUp   key.Binding `config:"move-up"`
+	MoveDown key.Binding `config:"move-down"`
+}
+
+type ViewKeyBindings struct {
+	PromptForCommand key.Binding `config:"prompt-for-command"`
+	Forward          key.Binding `config:"forward"`
+	Edit             key.Binding `config:"edit"`
+	Yank             key.Binding `config:"yank"`
+	Delete           key.Binding `config:"delete"`
+	ToggleMark       key.Binding `config:"toggle-mark"`
+	Redrive          key.Binding `config:"redrive"`
+	Filter           key.Binding `config:"filter"`
+	Find             key.Binding `config:"find"`
+	NextMatch        key.Binding `config:"next-match"`
+	PrevMatch        key.Binding `config:"prev-match"`
+	Quit             key.Binding `config:"quit"`
+}
+
+func Default() *KeyBindings {
+	return &KeyBindings{
+		Table: &TableKeyBinding{
+			MoveUp:   key.NewBinding(key.WithKeys("up", "i")),
+			MoveDown: key.NewBinding(key.WithKeys("down", "k")),
+		},
+		View: &ViewKeyBindings{
+			PromptForCommand: key.NewBinding(key.WithKeys(":")),
+			Forward:          key.NewBinding(key.WithKeys("f")),
+			Edit:             key.NewBinding(key.WithKeys("e")),
+			Yank:             key.NewBinding(key.WithKeys("y")),
+			Delete:           key.NewBinding(key.WithKeys("d")),
+			ToggleMark:       key.NewBinding(key.WithKeys("m")),
+			Redrive:          key.NewBinding(key.WithKeys("R")),
+			Filter:           key.NewBinding(key.WithKeys("F")),
+			Find:             key.NewBinding(key.WithKeys("/")),
+			NextMatch:        key.NewBinding(key.WithKeys("n")),
+			PrevMatch:        key.NewBinding(key.WithKeys("N")),
+			Quit:             key.NewBinding(key.WithKeys("ctrl+c", "q")),
+		},
+	}
+}
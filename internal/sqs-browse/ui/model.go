@@ -1,98 +1,310 @@
 package ui
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"fmt"
 	"log"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lmika/audax/internal/common/ui/commandctrl"
 	"github.com/lmika/audax/internal/common/ui/dispatcher"
 	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/common/userconfig"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/helpview"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/statusandprompt"
 	"github.com/lmika/audax/internal/sqs-browse/controllers"
 	"github.com/lmika/audax/internal/sqs-browse/models"
+	"github.com/lmika/audax/internal/sqs-browse/models/filterexpr"
+	"github.com/lmika/audax/internal/sqs-browse/styles"
+	"github.com/lmika/audax/internal/sqs-browse/ui/keybindings"
+	"github.com/lmika/audax/internal/sqs-browse/ui/queueselect"
 	table "github.com/lmika/go-bubble-table"
-)
-
-var (
-	activeHeaderStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("#ffffff")).
-				Background(lipgloss.Color("#eac610"))
-
-	inactiveHeaderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#000000")).
-				Background(lipgloss.Color("#d1d1d1"))
+	"github.com/pkg/errors"
 )
 
 type uiModel struct {
-	table    table.Model
-	viewport viewport.Model
-
-	ready     bool
-	tableRows []table.Row
-	message   string
-
-	pendingInput *events.PromptForInputMsg
-	textInput    textinput.Model
+	table       table.Model
+	viewport    viewport.Model
+	queueSelect *queueselect.Model
+
+	ready       bool
+	messages    []models.Message
+	marked      map[string]bool
+	tableRows   []table.Row
+	searchQuery string
+	filter      *filterexpr.FilterExpr
+	message     string
+
+	// The message shown in the detail, and the lines of the detail matching the find term
+	detailMessageID string
+	findTerm        string
+	findPattern     *regexp.Regexp
+	matchingLines   []int
+	currentMatch    int
+
+	prompt *statusandprompt.Prompt
+
+	// The help shown when requested with the help command
+	helpVisible     bool
+	helpViewport    viewport.Model
+	keyBindingsHelp []userconfig.BindingDescription
 
 	dispatcher         *dispatcher.Dispatcher
+	cmdController      *commandctrl.CommandController
 	msgSendingHandlers *controllers.MessageSendingController
+	pollController     *controllers.PollController
+	redriveController  *controllers.RedriveController
+	workspaceCtrl      *controllers.WorkspaceController
+	keyBindings        *keybindings.KeyBindings
+	uiStyles           styles.Styles
 }
 
-func NewModel(dispatcher *dispatcher.Dispatcher, msgSendingHandlers *controllers.MessageSendingController) tea.Model {
-	tbl := table.New(table.SimpleColumns{"seq", "message"}, 100, 20)
+func NewModel(
+	dispatcher *dispatcher.Dispatcher,
+	cmdController *commandctrl.CommandController,
+	msgSendingHandlers *controllers.MessageSendingController,
+	pollController *controllers.PollController,
+	redriveController *controllers.RedriveController,
+	workspaceCtrl *controllers.WorkspaceController,
+	keyBindings *keybindings.KeyBindings,
+	uiStyles styles.Styles,
+) tea.Model {
+	tbl := table.New(table.SimpleColumns{"", "seq", "tags", "message"}, 100, 20)
 	rows := make([]table.Row, 0)
 	tbl.SetRows(rows)
 
-	textInput := textinput.New()
-
 	model := uiModel{
 		table:              tbl,
+		queueSelect:        queueselect.New(keyBindings.Table, uiStyles.Frames),
 		tableRows:          rows,
+		marked:             make(map[string]bool),
 		message:            "",
-		textInput:          textInput,
+		prompt:             statusandprompt.NewPrompt(),
+		helpViewport:       viewport.New(0, 0),
+		keyBindingsHelp:    userconfig.DescribeKeyBindings(keyBindings),
 		msgSendingHandlers: msgSendingHandlers,
+		pollController:     pollController,
+		redriveController:  redriveController,
+		workspaceCtrl:      workspaceCtrl,
+		cmdController:      cmdController,
 		dispatcher:         dispatcher,
+		keyBindings:        keyBindings,
+		uiStyles:           uiStyles,
 	}
 
+	cmdController.AddCommands(&commandctrl.CommandContext{
+		Commands: map[string]commandctrl.Command{
+			"queue": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return pollController.ListQueues()
+				}
+				return pollController.SelectQueue(args[0])
+			},
+			"search": func(args []string) tea.Cmd {
+				return searchFor(strings.Join(args, " "))
+			},
+			"filter": func(args []string) tea.Cmd {
+				return workspaceCtrl.Filter(strings.Join(args, " "))
+			},
+			"find": func(args []string) tea.Cmd {
+				return findInDetail(strings.Join(args, " "))
+			},
+			"tag": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return events.SetError(errors.New("expected tags"))
+				}
+				return onSelectedMessage(func(message models.Message) tea.Cmd {
+					return workspaceCtrl.Tag(message, args)
+				})
+			},
+			"untag": func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return events.SetError(errors.New("expected tags"))
+				}
+				return onSelectedMessage(func(message models.Message) tea.Cmd {
+					return workspaceCtrl.Untag(message, args)
+				})
+			},
+			"annotate": func(args []string) tea.Cmd {
+				return onSelectedMessage(func(message models.Message) tea.Cmd {
+					return workspaceCtrl.Annotate(message, strings.Join(args, " "))
+				})
+			},
+			"delete": func(args []string) tea.Cmd {
+				return onSelectedMessage(workspaceCtrl.DeleteMessage)
+			},
+			"edit": func(args []string) tea.Cmd {
+				inline := false
+				delay := time.Duration(-1)
+				destQueue := ""
+				for len(args) > 0 {
+					switch args[0] {
+					case "-inline":
+						inline = true
+					case "-delay":
+						if len(args) < 2 {
+							return events.SetError(errors.New("expected delay in seconds"))
+						}
+						seconds, err := strconv.Atoi(args[1])
+						if err != nil {
+							return events.SetError(errors.Errorf("invalid delay: %v", args[1]))
+						}
+						delay = time.Duration(seconds) * time.Second
+						args = args[1:]
+					default:
+						destQueue = args[0]
+					}
+					args = args[1:]
+				}
+
+				return onSelectedMessage(func(message models.Message) tea.Cmd {
+					if inline {
+						return msgSendingHandlers.EditMessageInline(message, destQueue, delay)
+					}
+					return msgSendingHandlers.EditMessage(message, destQueue, delay)
+				})
+			},
+			"compose": func(args []string) tea.Cmd {
+				ca, err := parseComposeArgs(args)
+				if err != nil {
+					return events.SetError(err)
+				}
+
+				destQueue := ca.queue
+				if destQueue == "" {
+					destQueue = pollController.Queue()
+				}
+
+				switch {
+				case ca.jsonlFile != "":
+					return msgSendingHandlers.SendMessagesFromFile(ca.message, ca.jsonlFile, destQueue, ca.opts)
+				case ca.file != "":
+					return msgSendingHandlers.ComposeMessageFromFile(ca.message, ca.file, destQueue, ca.opts)
+				}
+				return msgSendingHandlers.ComposeMessage(ca.message, destQueue, ca.opts)
+			},
+			"redrive": func(args []string) tea.Cmd {
+				destQueue := ""
+				if len(args) > 0 {
+					destQueue = args[0]
+				}
+				return onMarkedMessages(func(msgs []models.Message) tea.Cmd {
+					return redriveController.Redrive(msgs, destQueue)
+				})
+			},
+			"unmark": func(args []string) tea.Cmd {
+				return onMarkedMessages(func(msgs []models.Message) tea.Cmd {
+					return func() tea.Msg { return unmarkMessages(msgs) }
+				})
+			},
+		},
+		Help: map[string]commandctrl.CommandHelp{
+			"queue": {
+				Description: "select the queue to poll, either from a list or by name or URL",
+				Args:        []commandctrl.ArgSpec{{Name: "queue", Optional: true}},
+			},
+			"search": {
+				Description: "only show messages containing the text, or show all messages if no text is given",
+				Args:        []commandctrl.ArgSpec{{Name: "text", Optional: true}},
+			},
+			"filter": {
+				Description: "only show messages matching a filter expression over the body and attributes, such as '$.detail.type == \"OrderPlaced\" and @source ^= \"billing\"', or clear the filter",
+				Args:        []commandctrl.ArgSpec{{Name: "expression", Optional: true}},
+			},
+			"find": {
+				Description: "highlight and scroll to text, or /regexp/, within the selected message, or clear the find",
+				Args:        []commandctrl.ArgSpec{{Name: "text", Optional: true}},
+			},
+			"tag": {
+				Description: "add tags to the selected message",
+				Args:        []commandctrl.ArgSpec{{Name: "tag"}},
+			},
+			"untag": {
+				Description: "remove tags from the selected message",
+				Args:        []commandctrl.ArgSpec{{Name: "tag"}},
+			},
+			"annotate": {
+				Description: "set the note of the selected message, or remove it if no note is given",
+				Args:        []commandctrl.ArgSpec{{Name: "note", Optional: true}},
+			},
+			"delete": {Description: "remove the selected message from the workspace"},
+			"edit": {
+				Description: "edit the selected message in $EDITOR, or on the prompt with -inline, then send it to the target queue, or the given queue",
+				Args: []commandctrl.ArgSpec{
+					{Name: "-inline", Optional: true},
+					{Name: "-delay seconds", Optional: true},
+					{Name: "queue-url", Optional: true},
+				},
+			},
+			"compose": {
+				Description: "send a new message to the current queue, or the queue given with -q; the body is given inline, read with -f, or written in $EDITOR if omitted, while -jsonl sends each line of a file",
+				Args: []commandctrl.ArgSpec{
+					{Name: "-q queue-url", Optional: true},
+					{Name: "-f file", Optional: true, Completer: commandctrl.CompleteFiles},
+					{Name: "-jsonl file", Optional: true, Completer: commandctrl.CompleteFiles},
+					{Name: "-attr name[:type]=value", Optional: true},
+					{Name: "-delay seconds", Optional: true},
+					{Name: "-group id", Optional: true},
+					{Name: "-dedup id", Optional: true},
+					{Name: "body", Optional: true},
+				},
+			},
+			"redrive": {
+				Description: "send the marked messages to a queue, deleting the originals when received in peek mode; defaults to the queue using this queue as its dead-letter queue",
+				Args:        []commandctrl.ArgSpec{{Name: "queue-url", Optional: true}},
+			},
+			"unmark": {Description: "unmark all marked messages"},
+		},
+	})
+
 	return model
 }
 
 func (m uiModel) Init() tea.Cmd {
-	return nil
+	return tea.Batch(
+		m.workspaceCtrl.LoadMessages(),
+		m.cmdController.Sequence(m.pollController.Init(), m.cmdController.Init()),
+	)
 }
 
 func (m *uiModel) updateViewportToSelectedMessage() {
-	if message, ok := m.selectedMessage(); ok {
-		// TODO: not all messages are JSON
-		formattedJson := new(bytes.Buffer)
-		if err := json.Indent(formattedJson, []byte(message.Data), "", "   "); err == nil {
-			m.viewport.SetContent(formattedJson.String())
-		} else {
-			m.viewport.SetContent(message.Data)
-		}
-	} else {
+	message, ok := m.selectedMessage()
+	if !ok {
+		m.detailMessageID, m.matchingLines = "", nil
 		m.viewport.SetContent("(no message selected)")
+		return
+	}
+
+	if message.ExtID != m.detailMessageID {
+		m.detailMessageID = message.ExtID
+		m.currentMatch = -1
+	}
+
+	detail := messageDetail(message)
+	m.matchingLines = nil
+	if _, pattern := m.highlightTerm(); pattern != nil {
+		detail, m.matchingLines = highlightMatches(detail, pattern)
 	}
+	m.viewport.SetContent(detail)
 }
 
 func (m uiModel) selectedMessage() (models.Message, bool) {
-	if m.ready && len(m.tableRows) > 0 {
-		if message, ok := m.table.SelectedRow().(messageTableRow); ok {
-			return models.Message(message), true
+	if m.ready && len(m.tableRows) > 0 && m.table.Cursor() < len(m.tableRows) {
+		if row, ok := m.table.SelectedRow().(messageTableRow); ok {
+			return row.message, true
 		}
 	}
 	return models.Message{}, false
 }
 
 func (m uiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var textInputCommands tea.Cmd
-
 	switch msg := msg.(type) {
 	// Shared messages
 	case events.ErrorMsg:
@@ -100,18 +312,75 @@ func (m uiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case events.StatusMsg:
 		m.message = string(msg)
 	case events.PromptForInputMsg:
-		// TODO
-		//m.textInput.Focus()
-		//m.textInput.SetValue("")
-		//m.pendingInput = &msg
+		return m, m.prompt.Start(msg)
+	case commandctrl.ShowHelpMsg:
+		m.helpVisible = true
+		m.helpViewport.SetContent(helpview.HelpText(msg.Commands, m.keyBindingsHelp))
+		m.helpViewport.GotoTop()
+		return m, nil
 
 	// Local messages
+	case controllers.PromptForQueueMsg:
+		return m, m.queueSelect.Update(msg)
+	case controllers.QueueSelected:
+		m.queueSelect.Update(msg)
+		m.message = "Polling " + models.QueueName(msg.Queue)
 	case NewMessagesEvent:
 		for _, newMsg := range msg {
-			m.tableRows = append(m.tableRows, messageTableRow(*newMsg))
+			if m.filter == nil || m.filter.Matches(*newMsg) {
+				m.messages = append(m.messages, *newMsg)
+			}
+		}
+		m.refreshRows()
+	case controllers.WorkspaceLoaded:
+		m.mergeMessages(msg.Messages)
+		m.message = fmt.Sprintf("%d messages in workspace", len(msg.Messages))
+	case controllers.WorkspaceFiltered:
+		m.filter = msg.Filter
+		m.mergeMessages(msg.Messages)
+		if m.filter != nil {
+			m.message = fmt.Sprintf("%d messages matching filter", len(m.messages))
+		} else {
+			m.message = "filter cleared"
 		}
-		m.table.SetRows(m.tableRows)
-		m.updateViewportToSelectedMessage()
+	case controllers.MessageUpdated:
+		for i, message := range m.messages {
+			if message.ID == msg.Message.ID {
+				m.messages[i] = msg.Message
+				break
+			}
+		}
+		m.refreshRows()
+	case controllers.MessageDeleted:
+		m.removeMessage(msg.Message)
+	case controllers.MessageRemoved:
+		m.removeMessage(msg.Message)
+	case setSearchQuery:
+		m.searchQuery = string(msg)
+		m.refreshRows()
+		if m.searchQuery != "" {
+			m.message = fmt.Sprintf("%d messages matching '%v'", len(m.tableRows), m.searchQuery)
+		}
+	case setFindTerm:
+		m.setFindTerm(string(msg))
+	case withMarkedMessages:
+		return m, msg(m.markedMessages())
+	case unmarkMessages:
+		for _, message := range msg {
+			delete(m.marked, message.ExtID)
+		}
+		m.refreshRows()
+	case controllers.MessagesRedriven:
+		for _, message := range msg.Redriven {
+			delete(m.marked, message.ExtID)
+		}
+		m.refreshRows()
+		m.message = redriveSummary(msg)
+	case withSelectedMessage:
+		if selectedMessage, ok := m.selectedMessage(); ok {
+			return m, msg(selectedMessage)
+		}
+		m.message = "Error: no message selected"
 
 	case tea.WindowSizeMsg:
 		fixedViewsHeight := lipgloss.Height(m.headerView()) + lipgloss.Height(m.splitterView()) + lipgloss.Height(m.footerView())
@@ -132,45 +401,98 @@ func (m uiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewport.Height = msg.Height - tableHeight - fixedViewsHeight
 		}
 
-		m.textInput.Width = msg.Width
-
-		m.textInput, textInputCommands = m.textInput.Update(msg)
+		m.prompt.SetWidth(msg.Width)
+		m.queueSelect.SetSize(msg.Width, msg.Height-lipgloss.Height(m.footerView()))
+		m.helpViewport.Width = msg.Width
+		m.helpViewport.Height = msg.Height - lipgloss.Height(m.helpTitleView()) - lipgloss.Height(m.footerView())
 	case tea.KeyMsg:
 
 		// If text input in focus, allow that to accept input messages
-		if m.pendingInput != nil {
+		if m.prompt.InPrompt() {
+			return m, m.prompt.Update(msg)
+		}
+
+		if m.helpVisible {
 			switch msg.String() {
-			case "ctrl+c", "esc":
-				m.pendingInput = nil
-			case "enter":
-				//m.dispatcher.Start(uimodels.WithPromptValue(context.Background(), m.textInput.Value()), m.pendingInput.OnDone)
-				m.pendingInput = nil
-			default:
-				m.textInput, textInputCommands = m.textInput.Update(msg)
+			case "esc", "q":
+				m.helpVisible = false
+				return m, nil
 			}
-			break
+
+			var cmd tea.Cmd
+			m.helpViewport, cmd = m.helpViewport.Update(msg)
+			return m, cmd
+		}
+
+		if m.queueSelect.Visible() {
+			return m, m.queueSelect.Update(msg)
 		}
 
 		// Normal focus
-		switch msg.String() {
+		switch {
 
-		case "ctrl+c", "q":
+		case key.Matches(msg, m.keyBindings.View.PromptForCommand):
+			return m, m.cmdController.Prompt()
+		case key.Matches(msg, m.keyBindings.View.Quit):
 			return m, tea.Quit
-		case "up", "i":
+		case key.Matches(msg, m.keyBindings.Table.MoveUp):
 			m.table.GoUp()
 			m.updateViewportToSelectedMessage()
-		case "down", "k":
+		case key.Matches(msg, m.keyBindings.Table.MoveDown):
 			m.table.GoDown()
 			m.updateViewportToSelectedMessage()
 
 		// TODO: these should be moved somewhere else
-		case "f":
+		case key.Matches(msg, m.keyBindings.View.ToggleMark):
+			if selectedMessage, ok := m.selectedMessage(); ok {
+				if m.marked[selectedMessage.ExtID] {
+					delete(m.marked, selectedMessage.ExtID)
+				} else {
+					m.marked[selectedMessage.ExtID] = true
+				}
+				m.refreshRows()
+			}
+		case key.Matches(msg, m.keyBindings.View.Filter):
+			initialValue := ""
+			if m.filter != nil {
+				initialValue = m.filter.String()
+			}
+			return m, func() tea.Msg {
+				return events.PromptForInputMsg{Prompt: "filter: ", InitialValue: initialValue, OnDone: m.workspaceCtrl.Filter}
+			}
+		case key.Matches(msg, m.keyBindings.View.Find):
+			findTerm := m.findTerm
+			return m, func() tea.Msg {
+				return events.PromptForInputMsg{Prompt: "find: ", InitialValue: findTerm, OnDone: findInDetail}
+			}
+		case key.Matches(msg, m.keyBindings.View.NextMatch):
+			m.jumpToMatch(1)
+			return m, nil
+		case key.Matches(msg, m.keyBindings.View.PrevMatch):
+			m.jumpToMatch(-1)
+			return m, nil
+		case key.Matches(msg, m.keyBindings.View.Redrive):
+			return m, m.redriveController.Redrive(m.markedMessages(), "")
+		case key.Matches(msg, m.keyBindings.View.Forward):
 			if selectedMessage, ok := m.selectedMessage(); ok {
 				m.dispatcher.Start(context.Background(), m.msgSendingHandlers.ForwardMessage(selectedMessage))
 			}
+		case key.Matches(msg, m.keyBindings.View.Edit):
+			if selectedMessage, ok := m.selectedMessage(); ok {
+				return m, m.msgSendingHandlers.EditMessage(selectedMessage, "", -1)
+			}
+		case key.Matches(msg, m.keyBindings.View.Yank):
+			if selectedMessage, ok := m.selectedMessage(); ok {
+				m.dispatcher.Start(context.Background(), controllers.YankMessageBody(selectedMessage))
+			}
+		case key.Matches(msg, m.keyBindings.View.Delete):
+			if selectedMessage, ok := m.selectedMessage(); ok {
+				return m, events.Confirm("delete message from queue? ", func() tea.Cmd {
+					m.dispatcher.Start(context.Background(), m.pollController.DeleteMessage(selectedMessage))
+					return nil
+				})
+			}
 		}
-	default:
-		m.textInput, textInputCommands = m.textInput.Update(msg)
 	}
 
 	updatedTable, tableMsgs := m.table.Update(msg)
@@ -179,7 +501,59 @@ func (m uiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.table = updatedTable
 	m.viewport = updatedViewport
 
-	return m, tea.Batch(textInputCommands, tableMsgs, viewportMsgs)
+	return m, tea.Batch(m.prompt.Update(msg), tableMsgs, viewportMsgs)
+}
+
+// refreshRows rebuilds the table rows from the messages matching the search query.
+func (m *uiModel) refreshRows() {
+	m.tableRows = make([]table.Row, 0, len(m.messages))
+	for _, message := range m.messages {
+		if m.searchQuery == "" || message.Matches(m.searchQuery) {
+			m.tableRows = append(m.tableRows, messageTableRow{message: message, marked: m.marked[message.ExtID]})
+		}
+	}
+	m.table.SetRows(m.tableRows)
+	m.updateViewportToSelectedMessage()
+}
+
+// mergeMessages replaces the messages with those loaded from the workspace.  Messages received while the
+// workspace was being read are kept if they match the filter.
+func (m *uiModel) mergeMessages(loadedMessages []models.Message) {
+	loadedIDs := make(map[uint64]struct{}, len(loadedMessages))
+	for _, message := range loadedMessages {
+		loadedIDs[message.ID] = struct{}{}
+	}
+
+	newMessages := loadedMessages
+	for _, message := range m.messages {
+		if _, loaded := loadedIDs[message.ID]; !loaded && (m.filter == nil || m.filter.Matches(message)) {
+			newMessages = append(newMessages, message)
+		}
+	}
+	m.messages = newMessages
+	m.refreshRows()
+}
+
+// markedMessages returns the marked messages in the order they were received.
+func (m uiModel) markedMessages() []models.Message {
+	markedMessages := make([]models.Message, 0, len(m.marked))
+	for _, message := range m.messages {
+		if m.marked[message.ExtID] {
+			markedMessages = append(markedMessages, message)
+		}
+	}
+	return markedMessages
+}
+
+func (m *uiModel) removeMessage(message models.Message) {
+	for i, msg := range m.messages {
+		if msg.ExtID == message.ExtID {
+			m.messages = append(m.messages[:i:i], m.messages[i+1:]...)
+			delete(m.marked, message.ExtID)
+			break
+		}
+	}
+	m.refreshRows()
 }
 
 func (m uiModel) View() string {
@@ -187,14 +561,12 @@ func (m uiModel) View() string {
 		return "Initializing"
 	}
 
-	if m.pendingInput != nil {
-		return lipgloss.JoinVertical(lipgloss.Top,
-			m.headerView(),
-			m.table.View(),
-			m.splitterView(),
-			m.viewport.View(),
-			m.textInput.View(),
-		)
+	if m.helpVisible {
+		return lipgloss.JoinVertical(lipgloss.Top, m.helpTitleView(), m.helpViewport.View(), m.footerView())
+	}
+
+	if m.queueSelect.Visible() {
+		return lipgloss.JoinVertical(lipgloss.Top, m.queueSelect.View(), m.footerView())
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Top,
@@ -207,18 +579,42 @@ func (m uiModel) View() string {
 }
 
 func (m uiModel) headerView() string {
-	title := activeHeaderStyle.Render("Queue: XXX")
-	line := activeHeaderStyle.Render(strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(title))))
+	queueName := "(none)"
+	if queue := m.pollController.Queue(); queue != "" {
+		queueName = models.QueueName(queue)
+	}
+	titleText := "Queue: " + queueName + " [" + m.pollController.Mode().String() + "]"
+	if m.filter != nil {
+		titleText += " Filter: " + m.filter.String()
+	}
+	title := m.uiStyles.Frames.ActiveTitle.Render(titleText)
+	line := m.uiStyles.Frames.ActiveTitle.Render(strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(title))))
+	return lipgloss.JoinHorizontal(lipgloss.Left, title, line)
+}
+
+func (m uiModel) helpTitleView() string {
+	title := m.uiStyles.Frames.ActiveTitle.Render("Help")
+	line := m.uiStyles.Frames.ActiveTitle.Render(strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(title))))
 	return lipgloss.JoinHorizontal(lipgloss.Left, title, line)
 }
 
+// splitterView returns the title of the message detail, or the completions of the prompt if there are any.
 func (m uiModel) splitterView() string {
-	title := inactiveHeaderStyle.Render("Message")
-	line := inactiveHeaderStyle.Render(strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(title))))
+	titleText := "Message"
+	if completionsLine := m.prompt.CompletionsLine(); completionsLine != "" {
+		titleText = completionsLine
+	}
+	title := m.uiStyles.Frames.InactiveTitle.Render(titleText)
+	line := m.uiStyles.Frames.InactiveTitle.Render(strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(title))))
 	return lipgloss.JoinHorizontal(lipgloss.Left, title, line)
 }
 
+// footerView returns the prompt if it is being shown, otherwise the status message.
 func (m uiModel) footerView() string {
+	if m.prompt.InPrompt() {
+		return m.prompt.View()
+	}
+
 	title := m.message
 	line := strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(title)))
 	return lipgloss.JoinHorizontal(lipgloss.Left, title, line)
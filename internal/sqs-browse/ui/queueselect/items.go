@@ -0,0 +1,43 @@
+package queueselect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
+
+type queueItem struct {
+	queue models.QueueInfo
+}
+
+func (qi queueItem) FilterValue() string {
+	return qi.queue.Name
+}
+
+func (qi queueItem) Title() string {
+	if qi.queue.FIFO {
+		return qi.queue.Name + " [FIFO]"
+	}
+	return qi.queue.Name
+}
+
+func (qi queueItem) Description() string {
+	desc := []string{fmt.Sprintf("%d available, %d in flight", qi.queue.ApproximateMessages, qi.queue.ApproximateMessagesInFlight)}
+	if qi.queue.DeadLetterQueue != "" {
+		desc = append(desc, "DLQ: "+qi.queue.DeadLetterQueue)
+	}
+	if len(qi.queue.DeadLetterSources) > 0 {
+		desc = append(desc, "DLQ of: "+strings.Join(qi.queue.DeadLetterSources, ", "))
+	}
+	return strings.Join(desc, " · ")
+}
+
+func toListItems(queues []models.QueueInfo) []list.Item {
+	ls := make([]list.Item, len(queues))
+	for i, q := range queues {
+		ls[i] = queueItem{queue: q}
+	}
+	return ls
+}
@@ -0,0 +1,99 @@
+package queueselect
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/frame"
+	"github.com/lmika/audax/internal/sqs-browse/controllers"
+	"github.com/lmika/audax/internal/sqs-browse/ui/keybindings"
+)
+
+// Model is a list of queues to select from.  The list is only visible while a selection is pending.
+type Model struct {
+	frameTitle       frame.FrameTitle
+	list             list.Model
+	pendingSelection *controllers.PromptForQueueMsg
+	keyBinding       *keybindings.TableKeyBinding
+	w, h             int
+}
+
+func New(keyBinding *keybindings.TableKeyBinding, frameStyle frame.Style) *Model {
+	return &Model{
+		frameTitle: frame.NewFrameTitle("Select queue", true, frameStyle),
+		keyBinding: keyBinding,
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case controllers.PromptForQueueMsg:
+		m.pendingSelection = &msg
+		m.list = m.newList(msg)
+	case controllers.QueueSelected:
+		// A queue was selected by other means, such as via a command
+		m.pendingSelection = nil
+	case tea.KeyMsg:
+		if m.pendingSelection == nil {
+			return nil
+		}
+
+		if m.list.FilterState() != list.Filtering {
+			switch msg.String() {
+			case "enter":
+				selectedItem, ok := m.list.SelectedItem().(queueItem)
+				if !ok {
+					return nil
+				}
+				var sel controllers.PromptForQueueMsg
+				sel, m.pendingSelection = *m.pendingSelection, nil
+				return sel.OnSelected(selectedItem.queue.URL)
+			case "esc":
+				if m.list.FilterState() == list.Unfiltered {
+					m.pendingSelection = nil
+					return nil
+				}
+			}
+		}
+
+		newList, cmd := m.list.Update(msg)
+		m.list = newList
+		return cmd
+	}
+	return nil
+}
+
+func (m *Model) newList(msg controllers.PromptForQueueMsg) list.Model {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(lipgloss.Color("#2c5fb7")).
+		Foreground(lipgloss.Color("#2c5fb7")).
+		Padding(0, 0, 0, 1)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedTitle.Copy().
+		Foreground(lipgloss.Color("#5a82c8"))
+
+	l := list.New(toListItems(msg.Queues), delegate, m.w, m.h-m.frameTitle.HeaderHeight())
+	l.KeyMap.CursorUp = m.keyBinding.MoveUp
+	l.KeyMap.CursorDown = m.keyBinding.MoveDown
+	l.KeyMap.Quit.SetEnabled(false)
+	l.SetShowTitle(false)
+	return l
+}
+
+func (m *Model) View() string {
+	return lipgloss.JoinVertical(lipgloss.Top, m.frameTitle.View(), m.list.View())
+}
+
+// Visible returns true if a queue selection is pending.
+func (m *Model) Visible() bool {
+	return m.pendingSelection != nil
+}
+
+func (m *Model) SetSize(w, h int) {
+	m.w, m.h = w, h
+	m.frameTitle.Resize(w, h)
+	if m.pendingSelection != nil {
+		m.list.SetSize(w, h-m.frameTitle.HeaderHeight())
+	}
+}
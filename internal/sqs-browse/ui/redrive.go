@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lmika/audax/internal/sqs-browse/controllers"
+	"github.com/lmika/audax/internal/sqs-browse/models"
+)
+
+// maxReportedFailures is the maximum number of failures listed in the redrive summary
+const maxReportedFailures = 3
+
+// redriveSummary describes the outcome of a redrive.  Failed messages remain marked so they can be redriven again.
+func redriveSummary(msg controllers.MessagesRedriven) string {
+	queueName := models.QueueName(msg.Queue)
+	if len(msg.Failures) == 0 {
+		return fmt.Sprintf("Redrove %d messages to %v", len(msg.Redriven), queueName)
+	}
+
+	failures := make([]string, 0, maxReportedFailures)
+	for i, failure := range msg.Failures {
+		if i == maxReportedFailures {
+			failures = append(failures, fmt.Sprintf("and %d more", len(msg.Failures)-maxReportedFailures))
+			break
+		}
+		failures = append(failures, fmt.Sprintf("%d: %v", failure.Message.ID, failure.Err))
+	}
+
+	return fmt.Sprintf("Redrove %d of %d messages to %v, failures: %v",
+		len(msg.Redriven), len(msg.Redriven)+len(msg.Failures), queueName, strings.Join(failures, "; "))
+}
@@ -5,23 +5,39 @@ import (
 	"io"
 	"strings"
 
-	table "github.com/lmika/go-bubble-table"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/lmika/audax/internal/sqs-browse/models"
+	table "github.com/lmika/go-bubble-table"
 )
 
-type messageTableRow models.Message
+var markedRowStyle = lipgloss.NewStyle().
+	Background(lipgloss.AdaptiveColor{Light: "#e1e1e1", Dark: "#414141"})
+
+type messageTableRow struct {
+	message models.Message
+	marked  bool
+}
 
 func (mtr messageTableRow) Render(w io.Writer, model table.Model, index int) {
-	firstLine := strings.SplitN(string(mtr.Data), "\n", 2)[0]
+	firstLine := strings.SplitN(mtr.message.Data, "\n", 2)[0]
 
 	sb := strings.Builder{}
-	sb.WriteString(fmt.Sprintf("%d", mtr.ID))
+	if mtr.marked {
+		sb.WriteString("•")
+	}
+	sb.WriteString("\t")
+	sb.WriteString(fmt.Sprintf("%d", mtr.message.ID))
+	sb.WriteString("\t")
+	sb.WriteString(strings.Join(mtr.message.Tags, ","))
 	sb.WriteString("\t")
 	sb.WriteString(firstLine)
 
-	if index == model.Cursor() {
+	switch {
+	case index == model.Cursor():
 		fmt.Fprintln(w, model.Styles.SelectedRow.Render(sb.String()))
-	} else {
+	case mtr.marked:
+		fmt.Fprintln(w, markedRowStyle.Render(sb.String()))
+	default:
 		fmt.Fprintln(w, sb.String())
 	}
 }
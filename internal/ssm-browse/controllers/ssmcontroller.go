@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmika/audax/internal/common/clipboard"
 	"github.com/lmika/audax/internal/common/ui/events"
 	"github.com/lmika/audax/internal/ssm-browse/models"
 	"github.com/lmika/audax/internal/ssm-browse/services/ssmparameters"
@@ -57,6 +58,21 @@ func (c *SSMController) ChangePrefix(newPrefix string) tea.Cmd {
 	}
 }
 
+// Yank copies the value of the parameter to the clipboard.  If copyName is true, the name is copied instead.
+func (c *SSMController) Yank(param models.SSMParameter, copyName bool) tea.Cmd {
+	return func() tea.Msg {
+		what, text := "value", param.Value
+		if copyName {
+			what, text = "name", param.Name
+		}
+
+		if err := clipboard.Write(text); err != nil {
+			return events.Error(err)
+		}
+		return events.StatusMsg("copied " + what + " of " + param.Name + " to clipboard")
+	}
+}
+
 func (c *SSMController) Clone(param models.SSMParameter) tea.Cmd {
 	return events.PromptForInput("New key: ", func(value string) tea.Cmd {
 		return func() tea.Msg {
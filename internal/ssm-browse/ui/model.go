@@ -1,13 +1,17 @@
 package ui
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lmika/audax/internal/common/ui/commandctrl"
 	"github.com/lmika/audax/internal/common/ui/events"
+	"github.com/lmika/audax/internal/common/userconfig"
+	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/helpview"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/layout"
 	"github.com/lmika/audax/internal/dynamo-browse/ui/teamodels/statusandprompt"
 	"github.com/lmika/audax/internal/ssm-browse/controllers"
 	"github.com/lmika/audax/internal/ssm-browse/styles"
+	"github.com/lmika/audax/internal/ssm-browse/ui/keybindings"
 	"github.com/lmika/audax/internal/ssm-browse/ui/ssmdetails"
 	"github.com/lmika/audax/internal/ssm-browse/ui/ssmlist"
 	"github.com/pkg/errors"
@@ -17,18 +21,21 @@ type Model struct {
 	cmdController   *commandctrl.CommandController
 	controller      *controllers.SSMController
 	statusAndPrompt *statusandprompt.StatusAndPrompt
+	helpView        *helpview.Model
 
-	root       tea.Model
-	ssmList    *ssmlist.Model
-	ssmDetails *ssmdetails.Model
+	root        tea.Model
+	ssmList     *ssmlist.Model
+	ssmDetails  *ssmdetails.Model
+	keyBindings *keybindings.KeyBindings
 }
 
-func NewModel(controller *controllers.SSMController, cmdController *commandctrl.CommandController) Model {
-	defaultStyles := styles.DefaultStyles
-	ssmList := ssmlist.New(defaultStyles.Frames)
-	ssmdDetails := ssmdetails.New(defaultStyles.Frames)
-	statusAndPrompt := statusandprompt.New(
-		layout.NewVBox(layout.LastChildFixedAt(17), ssmList, ssmdDetails), "", defaultStyles.StatusAndPrompt)
+func NewModel(controller *controllers.SSMController, cmdController *commandctrl.CommandController, keyBindings *keybindings.KeyBindings, uiStyles styles.Styles) Model {
+	ssmList := ssmlist.New(keyBindings.List, uiStyles.Frames)
+	ssmdDetails := ssmdetails.New(uiStyles.Frames)
+	helpView := helpview.New(
+		layout.NewVBox(layout.LastChildFixedAt(17), ssmList, ssmdDetails),
+		userconfig.DescribeKeyBindings(keyBindings), uiStyles.Frames)
+	statusAndPrompt := statusandprompt.New(helpView, "", uiStyles.StatusAndPrompt)
 
 	cmdController.AddCommands(&commandctrl.CommandContext{
 		Commands: map[string]commandctrl.Command{
@@ -38,6 +45,12 @@ func NewModel(controller *controllers.SSMController, cmdController *commandctrl.
 				}
 				return events.SetError(errors.New("no parameter selected"))
 			},
+			"yank": func(args []string) tea.Cmd {
+				if currentParam := ssmList.CurrentParameter(); currentParam != nil {
+					return controller.Yank(*currentParam, len(args) > 0 && args[0] == "-name")
+				}
+				return events.SetError(errors.New("no parameter selected"))
+			},
 			"delete": func(args []string) tea.Cmd {
 				if currentParam := ssmList.CurrentParameter(); currentParam != nil {
 					return controller.DeleteParameter(*currentParam)
@@ -45,6 +58,14 @@ func NewModel(controller *controllers.SSMController, cmdController *commandctrl.
 				return events.SetError(errors.New("no parameter selected"))
 			},
 		},
+		Help: map[string]commandctrl.CommandHelp{
+			"clone":  {Description: "create a copy of the selected parameter"},
+			"delete": {Description: "delete the selected parameter"},
+			"yank": {
+				Description: "copy the value, or name, of the selected parameter",
+				Args:        []commandctrl.ArgSpec{{Name: "-name", Optional: true}},
+			},
+		},
 	})
 
 	root := layout.FullScreen(statusAndPrompt)
@@ -54,13 +75,15 @@ func NewModel(controller *controllers.SSMController, cmdController *commandctrl.
 		cmdController:   cmdController,
 		root:            root,
 		statusAndPrompt: statusAndPrompt,
+		helpView:        helpView,
 		ssmList:         ssmList,
 		ssmDetails:      ssmdDetails,
+		keyBindings:     keyBindings,
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return m.controller.Fetch()
+	return m.cmdController.Sequence(m.controller.Fetch(), m.cmdController.Init())
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -71,14 +94,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ssmlist.NewSSMParameterSelected:
 		m.ssmDetails.SetSelectedItem(msg)
 	case tea.KeyMsg:
-		if !m.statusAndPrompt.InPrompt() {
-			switch msg.String() {
+		if !m.statusAndPrompt.InPrompt() && !m.helpView.Visible() {
+			switch {
 			// TEMP
-			case ":":
+			case key.Matches(msg, m.keyBindings.View.PromptForCommand):
 				return m, m.cmdController.Prompt()
 			// END TEMP
 
-			case "ctrl+c", "q":
+			case key.Matches(msg, m.keyBindings.View.Yank):
+				if currentParam := m.ssmList.CurrentParameter(); currentParam != nil {
+					return m, m.controller.Yank(*currentParam, false)
+				}
+			case key.Matches(msg, m.keyBindings.View.Quit):
 				return m, tea.Quit
 			}
 		}